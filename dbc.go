@@ -0,0 +1,109 @@
+package dbf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DBC describes the tables of a Visual FoxPro database container (.dbc).
+//
+// A database container is itself a DBF table with one record per object
+// (database, table, field, index, relation, ...). Only the table and field
+// names are extracted; they recover the long field names of tables that
+// belong to the container, since the DBF header stores at most 10 characters.
+type DBC struct {
+	tables []DBCTable
+}
+
+// DBCTable is a table registered in a database container.
+type DBCTable struct {
+	Name   string
+	Fields []string // long field names, in table field order
+}
+
+// OpenDBC reads the table and field names stored in the database container
+// at the given path. Deleted objects are ignored.
+//
+// Example:
+//
+//	dbc, err := dbf.OpenDBC("sales.dbc")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	table, ok := dbc.Table("customers")
+//	if ok {
+//		names := table.FieldNames(reader.Fields())
+//		fmt.Println(names["CUSTOMER_F"]) // customer_full_name
+//	}
+func OpenDBC(path string, opts ...Option) (*DBC, error) {
+	reader, err := NewFromFile(path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("open dbc: %w", err)
+	}
+	defer reader.Close()
+
+	for _, name := range []string{"OBJECTID", "PARENTID", "OBJECTTYPE", "OBJECTNAME"} {
+		if _, ok := reader.field(name); !ok {
+			return nil, fmt.Errorf("open dbc: missing %s field", name)
+		}
+	}
+
+	dbc := &DBC{}
+	tables := make(map[string]int) // object id -> index in dbc.tables
+
+	for reader.Next() {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read dbc object: %w", err)
+		}
+		if record.Deleted {
+			continue
+		}
+
+		name := record.Data["OBJECTNAME"]
+		switch strings.ToLower(record.Data["OBJECTTYPE"]) {
+		case "table":
+			tables[record.Data["OBJECTID"]] = len(dbc.tables)
+			dbc.tables = append(dbc.tables, DBCTable{Name: name})
+		case "field":
+			// objects are stored after their parent
+			if i, ok := tables[record.Data["PARENTID"]]; ok {
+				dbc.tables[i].Fields = append(dbc.tables[i].Fields, name)
+			}
+		}
+	}
+
+	if err := reader.Err(); err != nil {
+		return nil, fmt.Errorf("read dbc: %w", err)
+	}
+
+	return dbc, nil
+}
+
+// Tables returns the tables of the container in definition order.
+func (d *DBC) Tables() []DBCTable {
+	return d.tables
+}
+
+// Table returns the table with the given name (case-insensitive).
+func (d *DBC) Table(name string) (DBCTable, bool) {
+	for _, table := range d.tables {
+		if strings.EqualFold(table.Name, name) {
+			return table, true
+		}
+	}
+	return DBCTable{}, false
+}
+
+// FieldNames maps the field names of the table's DBF header to their long
+// names, matching them by position. Fields without a long name are omitted.
+func (t DBCTable) FieldNames(fields []Field) map[string]string {
+	names := make(map[string]string, len(fields))
+	for i, field := range fields {
+		if i < len(t.Fields) {
+			names[field.Name] = t.Fields[i]
+		}
+	}
+	return names
+}
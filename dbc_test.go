@@ -0,0 +1,134 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// dbcObject is a row of a synthetic database container
+type dbcObject struct {
+	id, parent int32
+	objectType string
+	name       string
+	deleted    bool
+}
+
+// createDBC creates a minimal database container with OBJECTID, PARENTID,
+// OBJECTTYPE and OBJECTNAME fields, laid out like Visual FoxPro writes it
+func createDBC(objects []dbcObject) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(0x30) // Visual FoxPro
+	buf.WriteByte(124)
+	buf.WriteByte(3)
+	buf.WriteByte(1)
+
+	binary.Write(buf, binary.LittleEndian, uint32(len(objects)))
+	binary.Write(buf, binary.LittleEndian, uint16(32+32*4+1+263))
+	binary.Write(buf, binary.LittleEndian, uint16(1+4+4+10+128))
+	reserved := make([]byte, 20)
+	reserved[17] = 0x03
+	buf.Write(reserved)
+
+	writeField := func(name string, fieldType byte, length byte) {
+		buf.Write(append([]byte(name), make([]byte, 11-len(name))...))
+		buf.WriteByte(fieldType)
+		buf.Write(make([]byte, 4))
+		buf.WriteByte(length)
+		buf.Write(make([]byte, 15))
+	}
+	writeField("OBJECTID", 'I', 4)
+	writeField("PARENTID", 'I', 4)
+	writeField("OBJECTTYPE", 'C', 10)
+	writeField("OBJECTNAME", 'C', 128)
+	buf.WriteByte(0x0D)
+	buf.Write(make([]byte, 263)) // backlink, empty for a container
+
+	for _, obj := range objects {
+		if obj.deleted {
+			buf.WriteByte(0x2A)
+		} else {
+			buf.WriteByte(0x20)
+		}
+		binary.Write(buf, binary.LittleEndian, obj.id)
+		binary.Write(buf, binary.LittleEndian, obj.parent)
+		buf.WriteString(padRight(obj.objectType, 10))
+		buf.WriteString(padRight(obj.name, 128))
+	}
+	buf.WriteByte(0x1A)
+
+	return buf.Bytes()
+}
+
+// padRight pads s with spaces to the given length
+func padRight(s string, length int) string {
+	return s + string(bytes.Repeat([]byte(" "), length-len(s)))
+}
+
+func TestOpenDBC(t *testing.T) {
+	path := writeTestFile(t, "sales.dbc", createDBC([]dbcObject{
+		{id: 1, parent: 1, objectType: "Database", name: "Database"},
+		{id: 2, parent: 1, objectType: "Table", name: "customers"},
+		{id: 3, parent: 2, objectType: "Field", name: "customer_full_name"},
+		{id: 4, parent: 2, objectType: "Field", name: "removed_field", deleted: true},
+		{id: 5, parent: 2, objectType: "Field", name: "amount"},
+		{id: 6, parent: 2, objectType: "Index", name: "cust_id"},
+		{id: 7, parent: 1, objectType: "Table", name: "orders"},
+		{id: 8, parent: 7, objectType: "Field", name: "order_id"},
+	}))
+
+	dbc, err := OpenDBC(path)
+	if err != nil {
+		t.Fatalf("OpenDBC() failed: %v", err)
+	}
+
+	if len(dbc.Tables()) != 2 {
+		t.Fatalf("Expected 2 tables, got %d", len(dbc.Tables()))
+	}
+
+	table, ok := dbc.Table("CUSTOMERS")
+	if !ok {
+		t.Fatal("Table() did not find customers")
+	}
+	if len(table.Fields) != 2 || table.Fields[0] != "customer_full_name" || table.Fields[1] != "amount" {
+		t.Errorf("Expected [customer_full_name amount], got %v", table.Fields)
+	}
+
+	names := table.FieldNames([]Field{{Name: "CUSTOMER_F"}, {Name: "AMOUNT"}})
+	if names["CUSTOMER_F"] != "customer_full_name" {
+		t.Errorf("Expected 'customer_full_name', got '%s'", names["CUSTOMER_F"])
+	}
+
+	if _, ok := dbc.Table("missing"); ok {
+		t.Error("Table() found a missing table")
+	}
+}
+
+func TestOpenDBCNotAContainer(t *testing.T) {
+	path := writeTestFile(t, "plain.dbf", createMinimalDBF())
+
+	if _, err := OpenDBC(path); err == nil {
+		t.Error("Expected error for a table without DBC fields, got nil")
+	}
+}
+
+func TestIntegerFieldDecoding(t *testing.T) {
+	reader, err := NewFromBytes(createDBC([]dbcObject{
+		{id: -5, parent: 70000, objectType: "Table", name: "t"},
+	}))
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+	if record.Data["OBJECTID"] != "-5" {
+		t.Errorf("Expected '-5', got '%s'", record.Data["OBJECTID"])
+	}
+	if record.Data["PARENTID"] != "70000" {
+		t.Errorf("Expected '70000', got '%s'", record.Data["PARENTID"])
+	}
+}
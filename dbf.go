@@ -30,11 +30,22 @@ package dbf
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"maps"
+	"math"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
@@ -70,6 +81,10 @@ func (ft FileType) String() string {
 		return "FoxPro 2.x (or earlier) with memo"
 	case HiPerSix:
 		return "HiPer-Six format with SMT memo file"
+	case dBASE7:
+		return "dBASE 7 without memo"
+	case dBASE7Memo:
+		return "dBASE 7 with memo"
 	default:
 		return fmt.Sprintf("Unknown (0x%02X)", byte(ft))
 	}
@@ -89,20 +104,89 @@ const (
 	dBASEIVTFMemo       FileType = 0xCB
 	FoxPro2             FileType = 0xF5
 	HiPerSix            FileType = 0xE5
+	dBASE7              FileType = 0x04
+	dBASE7Memo          FileType = 0x8C
 )
 
 const (
 	metadataLength uint16 = 32 // size of DBF file header in bytes
 	fieldLength    uint16 = 32 // size of field descriptor in bytes
+
+	dBASE7MetadataLength uint16 = 68 // dBASE 7 header: 32 bytes + language driver name (32) + reserved (4)
+	dBASE7FieldLength    uint16 = 48 // dBASE 7 field descriptor with 32-byte field name
+)
+
+var (
+	// ErrNotSeekable is returned by random-access methods and options when the
+	// underlying source does not implement io.ReaderAt or io.Seeker.
+	ErrNotSeekable = errors.New("dbf: source does not support random access")
+
+	// ErrOutOfRange is returned when a record index is outside the table.
+	ErrOutOfRange = errors.New("dbf: record index out of range")
+
+	// ErrUnknownField is returned when a field name is not part of the table.
+	ErrUnknownField = errors.New("dbf: unknown field")
+
+	// ErrReadOnly is returned by in-place modification methods when the
+	// underlying source does not implement io.WriterAt.
+	ErrReadOnly = errors.New("dbf: source is not writable")
+
+	// ErrStopIteration can be returned by the callback of PipeRecords or
+	// Transform to stop iteration early. The function then returns nil
+	// instead of an error. Wrapped errors are recognized with errors.Is.
+	ErrStopIteration = errors.New("dbf: stop iteration")
+
+	// ErrTruncated is returned when the file ends inside the header, e.g. in
+	// the middle of the field descriptors. It wraps the underlying io.EOF or
+	// io.ErrUnexpectedEOF.
+	ErrTruncated = errors.New("dbf: file truncated")
 )
 
+// FieldLengthMismatchError is returned when the record size declared in the
+// header does not match the sum of the field lengths plus the deletion flag.
+type FieldLengthMismatchError struct {
+	Declared uint16 // record size from the header
+	Computed uint16 // 1 + sum of field lengths
+}
+
+// Error implements the error interface.
+func (e *FieldLengthMismatchError) Error() string {
+	return fmt.Sprintf("record size mismatch: header declares %d bytes, fields add up to %d bytes", e.Declared, e.Computed)
+}
+
+// FieldError is returned when the value of a single field cannot be decoded.
+type FieldError struct {
+	Field string // name of the field that failed
+	Err   error  // underlying error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("decode field %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 // Field represents a single field definition in a DBF table.
 type Field struct {
-	Name          string // field name (max 11 characters)
-	Type          byte   // field type (C=Character, N=Numeric, D=Date, L=Logical, M=Memo, F=Float)
+	Name          string // field name (max 11 characters, 32 in dBASE 7)
+	RawName       string // field name exactly as stored in the file
+	Type          byte   // field type (C=Character, N=Numeric, D=Date, L=Logical, M=Memo, F=Float, G=General)
 	MemoryAddress uint32 // memory address (reserved, not used in file-based DBF)
 	Length        byte   // field length in bytes
 	DecimalCount  byte   // number of decimal places (for numeric fields)
+	Indexed       bool   // field has a production .mdx index tag (dBASE IV SQL tables only)
+}
+
+// SchemaEqual reports whether two field lists describe the same table layout:
+// the same field names, types, lengths and decimal counts in the same order.
+func SchemaEqual(a, b []Field) bool {
+	return slices.EqualFunc(a, b, func(x, y Field) bool {
+		return x.Name == y.Name && x.Type == y.Type && x.Length == y.Length && x.DecimalCount == y.DecimalCount
+	})
 }
 
 // TypeString returns a human-readable description of the field type.
@@ -120,11 +204,39 @@ func (f Field) TypeString() string {
 		return "Memo"
 	case 'F':
 		return "Float"
+	case 'G':
+		return "General"
+	case 'I':
+		return "Integer"
 	default:
 		return fmt.Sprintf("Unknown (%c)", f.Type)
 	}
 }
 
+// MaxIntegerDigits returns the number of characters available before the
+// decimal point of a numeric field: the length minus the decimal places and
+// the decimal point itself. The sign, if any, takes one of these characters.
+func (f Field) MaxIntegerDigits() int {
+	digits := int(f.Length) - int(f.DecimalCount)
+	if f.DecimalCount > 0 {
+		digits-- // decimal point
+	}
+	return digits
+}
+
+// MaxDecimalPrecision returns the number of digits after the decimal point
+// of a numeric field.
+func (f Field) MaxDecimalPrecision() int {
+	return int(f.DecimalCount)
+}
+
+// IsNullFlags reports whether the field is the hidden _NullFlags field that
+// Visual FoxPro adds to tables with nullable or variable-length fields. It is
+// a bitmap with one bit per such field.
+func (f Field) IsNullFlags() bool {
+	return f.Type == '0' && strings.EqualFold(f.Name, "_NullFlags")
+}
+
 // Reader provides methods for reading DBF files.
 // It supports both streaming (Next/Read) and batch (ReadAll) reading modes.
 type Reader struct {
@@ -134,14 +246,75 @@ type Reader struct {
 	headerBytesNumber uint16
 	recordBytesNumber uint16
 	fieldsCount       uint16
+	ldid              byte // Language Driver ID
+	tableFlags        byte // dBASE IV / Visual FoxPro table flags (header byte 28)
+	inTransaction     bool // dBASE IV incomplete transaction flag (header byte 14)
+	encrypted         bool // dBASE IV encryption flag (header byte 15)
 	fields            []Field
+	header            []byte // raw header bytes, including field descriptors
 
 	decoder       *encoding.Decoder
 	reader        *bufio.Reader
-	currentRecord uint32 // current position for Next()
-	err           error  // last error during reading
+	counter       *countingReader // counts bytes pulled from the source by reader
+	currentRecord uint32          // current position for Next()
+	err           error           // last error during reading
+
+	ra   io.ReaderAt // random access to the source, nil if not supported
+	wa   io.WriterAt // in-place modification of the source, nil if not supported
+	base int64       // offset of the DBF header within ra
+	size int64       // size of the DBF data from base, -1 if unknown
+
+	warnings []string // non-fatal issues found while reading
+
+	segments []*Reader // files iterated in sequence by a reader from OpenMulti
+	segment  int       // index of the current segment
+
+	upperCaseNames bool // normalize field names to upper case
+	dBASE7Mode     bool // parse dBASE 7 header and 48-byte field descriptors
+
+	permissiveFileType bool // accept unknown file type bytes
+
+	validateFieldLengths bool   // check record size against field lengths
+	autoAlign            bool   // use the field length sum as record size
+	acceptCountMismatch  bool   // limit record count to what the file can hold
+	countFromFile        bool   // always derive record count from file size
+	readToEOF            bool   // read records past the header count until EOF
+	extraRecords         uint32 // records found past the header count
+	pending              []byte // record read ahead by moreRecords
+	partialRecords       bool   // return partially decoded records on field errors
+	skipDeleted          bool   // skip records marked as deleted in Next()
+	clipperNumeric       bool   // binary floats in 4- and 8-byte numeric fields
+	ignoreDecodeErrors   bool   // report character decode failures as warnings
+	vfpDefaultEncoding   bool   // Windows-1252 for VFP tables without LDID
+
+	nullString string   // value reported for null (blank) fields
+	trimMode   TrimMode // padding removed from character values
+	datePivot  int      // two-digit year pivot for ParseDate, -1 if disabled
+
+	ctx         context.Context // cancels iteration, nil if not set
+	ctxInterval uint32          // check ctx every ctxInterval records
+
+	onWarning func(string)       // called for every warning
+	filter    func(*Record) bool // records kept by WriteFiltered, nil keeps all
+
+	customDecoder func(fieldType byte, data []byte) (string, error) // decodes unknown field types
+
+	memo          io.ReaderAt // memo file contents, nil if none attached
+	memoCloser    io.Closer   // memo file opened by the reader
+	memoPath      string      // memo file requested with WithMemoFile
+	memoFormat    MemoFormat  // layout of the attached memo file
+	memoBlockSize uint32      // bytes per memo block
+	memoSize      int64       // size of the memo file in bytes
+	memoBlockSet  uint32      // block size from WithMemoBlockSize, 0 if not set
+	noAutoMemo    bool        // do not open memo files automatically
+
+	fieldDecoders map[string]*encoding.Decoder // per-field decoders from WithFieldEncoding
+
+	aliases       map[string]string // original field name -> key in Record.Data
+	aliasedFields []Field           // fields renamed by aliases, nil if none
 
 	file *os.File
+	path string // file path when opened with NewFromFile
 }
 
 // Option is a functional option for configuring a Reader.
@@ -161,6 +334,32 @@ func WithEncoding(cm *charmap.Charmap) Option {
 	return WithDecoder(cm.NewDecoder())
 }
 
+// WithEncodingTransformer sets the text encoding using any encoding.Encoding,
+// including multi-byte encodings such as japanese.ShiftJIS,
+// simplifiedchinese.GBK or traditionalchinese.Big5 that are not charmaps.
+func WithEncodingTransformer(e encoding.Encoding) Option {
+	return WithDecoder(e.NewDecoder())
+}
+
+// WithFieldEncoding decodes the named character (C) or memo (M) field with
+// the given code page instead of the reader's encoding, for files whose
+// columns were written with different code pages. It can be given several
+// times for different fields. New returns an error wrapping ErrUnknownField
+// if the field does not exist.
+//
+// Example:
+//
+//	reader, err := dbf.NewFromFile("clients.dbf", dbf.WithCP866(),
+//		dbf.WithFieldEncoding("COMMENT", charmap.Windows1251))
+func WithFieldEncoding(name string, cm *charmap.Charmap) Option {
+	return func(r *Reader) {
+		if r.fieldDecoders == nil {
+			r.fieldDecoders = make(map[string]*encoding.Decoder)
+		}
+		r.fieldDecoders[name] = cm.NewDecoder()
+	}
+}
+
 // WithCP866 sets the encoding to Code Page 866 (Russian MS-DOS).
 // This is commonly used for Russian DBF files created in DOS.
 func WithCP866() Option {
@@ -179,6 +378,281 @@ func WithCP1252() Option {
 	return WithEncoding(charmap.Windows1252)
 }
 
+// WithVFPDefaultEncoding makes Visual FoxPro tables without a language
+// driver (LDID 0x00) decode as Windows-1252 instead of ISO-8859-1. Some VFP
+// generators leave the LDID empty and rely on the database container for
+// the code page; Windows-1252 is the VFP default. The assumption is
+// reported via Warnings(). An encoding set explicitly takes precedence.
+func WithVFPDefaultEncoding() Option {
+	return func(r *Reader) {
+		r.vfpDefaultEncoding = true
+	}
+}
+
+// WithFieldAliases renames fields on read: for every original field name in
+// aliases, Record.Data uses the alias as the key instead. Fields() still
+// returns the original names and AliasedFields() the names used in records.
+// Aliases that do not match a field are reported via Warnings().
+//
+// Example:
+//
+//	reader, err := dbf.NewFromFile("customers.dbf", dbf.WithFieldAliases(map[string]string{
+//		"CUST_NM": "CustomerName",
+//		"AMT_PD":  "AmountPaid",
+//	}))
+func WithFieldAliases(aliases map[string]string) Option {
+	return func(r *Reader) {
+		r.aliases = aliases
+	}
+}
+
+// WithUpperCaseNames converts all field names to upper case, so that
+// Record.Data keys are consistent regardless of how the file was written.
+// The original name is preserved in Field.RawName.
+func WithUpperCaseNames() Option {
+	return func(r *Reader) {
+		r.upperCaseNames = true
+	}
+}
+
+// WithDBase7Mode forces the dBASE 7 layout: a 68-byte header and 48-byte
+// field descriptors with field names of up to 32 characters.
+// dBASE 7 files are detected automatically by their file type byte;
+// use this option for files with a non-standard type byte.
+func WithDBase7Mode() Option {
+	return func(r *Reader) {
+		r.dBASE7Mode = true
+	}
+}
+
+// WithPermissiveFileType accepts files with an unrecognized file type byte
+// instead of failing, reading them with the dBASE III layout (no memo).
+// The raw byte is still reported by FileType() and a warning is recorded.
+func WithPermissiveFileType() Option {
+	return func(r *Reader) {
+		r.permissiveFileType = true
+	}
+}
+
+// WithValidateFieldLengths checks that the record size declared in the header
+// equals the sum of all field lengths plus the deletion flag byte.
+// If they differ, New returns a *FieldLengthMismatchError instead of reading
+// misaligned records.
+func WithValidateFieldLengths() Option {
+	return func(r *Reader) {
+		r.validateFieldLengths = true
+	}
+}
+
+// WithAutoAlign recovers files whose header declares a record size that
+// does not match the sum of the field lengths plus the deletion flag, as
+// written by some buggy generators (typically off by one). The computed size
+// is used instead of the declared one and a warning is added to Warnings().
+func WithAutoAlign() Option {
+	return func(r *Reader) {
+		r.autoAlign = true
+	}
+}
+
+// WithAcceptRecordCountMismatch limits the record count to the number of
+// records that actually fit in the file, for files whose header declares more
+// records than are present. The count is derived from the file size, so the
+// source must implement io.Seeker; otherwise the option is ignored.
+// Any discrepancy is reported via Warnings().
+func WithAcceptRecordCountMismatch() Option {
+	return func(r *Reader) {
+		r.acceptCountMismatch = true
+	}
+}
+
+// WithRecordCountFromFile always derives the record count from the file size
+// instead of trusting the header, for files from tools that store 0 there.
+// The source must implement io.Seeker; otherwise New returns ErrNotSeekable.
+// A discrepancy with the header is reported via Warnings().
+func WithRecordCountFromFile() Option {
+	return func(r *Reader) {
+		r.countFromFile = true
+	}
+}
+
+// WithReadToEOF makes Next() continue past the record count declared in the
+// header, for files whose count was not updated after records were appended.
+// Reading stops at the 0x1A end-of-file marker or at the end of the data; a
+// trailing partial record is ignored with a warning. RecordsCount() grows as
+// the additional records are reached.
+//
+// Example:
+//
+//	reader, err := dbf.NewFromFile("appended.dbf", dbf.WithReadToEOF())
+//	records, err := reader.ReadAll() // includes records beyond the header count
+func WithReadToEOF() Option {
+	return func(r *Reader) {
+		r.readToEOF = true
+	}
+}
+
+// WithSkipDeleted makes Next() skip records marked as deleted, so that
+// Next()/Read(), ReadAll() and functions built on them only return active
+// records. RecordsCount() still includes deleted records.
+func WithSkipDeleted() Option {
+	return func(r *Reader) {
+		r.skipDeleted = true
+	}
+}
+
+// WithCustomDecoder registers a decoder for field types the package does
+// not handle, such as vendor-specific types. fn receives the field type byte
+// and the raw field data and returns the value to store in Record.Data. If
+// fn returns an error, the value is decoded as character data, as it is
+// without a custom decoder. Built-in field types never reach fn.
+//
+// Example:
+//
+//	reader, err := dbf.NewFromFile("sites.dbf", dbf.WithCustomDecoder(
+//		func(fieldType byte, data []byte) (string, error) {
+//			if fieldType != 0x40 {
+//				return "", errors.New("unsupported")
+//			}
+//			return decodeCoordinate(data), nil
+//		}))
+func WithCustomDecoder(fn func(fieldType byte, data []byte) (string, error)) Option {
+	return func(r *Reader) {
+		r.customDecoder = fn
+	}
+}
+
+// WithFilter sets a predicate that selects the records copied by
+// WriteFiltered. Records for which keep returns false are omitted from the
+// output; sequential reading with Next()/Read() is not affected.
+//
+// Example:
+//
+//	reader, _ := dbf.NewFromFile("orders.dbf", dbf.WithFilter(func(rec *dbf.Record) bool {
+//		return rec.Data["STATUS"] == "A"
+//	}))
+func WithFilter(keep func(*Record) bool) Option {
+	return func(r *Reader) {
+		r.filter = keep
+	}
+}
+
+// WithPartialRecords makes Read() return the partially decoded record
+// together with a *FieldError when a field cannot be decoded, instead of
+// discarding the record. Such errors do not stop iteration, so callers can
+// log the row and continue with the next one. A last record cut short by
+// the end of the file is returned the same way, with the fields it holds.
+//
+// Example:
+//
+//	for reader.Next() {
+//		record, err := reader.Read()
+//		var fieldErr *dbf.FieldError
+//		if errors.As(err, &fieldErr) {
+//			log.Printf("field %s: %v, partial record: %v", fieldErr.Field, fieldErr.Err, record.Data)
+//			continue
+//		}
+//		...
+//	}
+func WithPartialRecords() Option {
+	return func(r *Reader) {
+		r.partialRecords = true
+	}
+}
+
+// WithClipperNumericFormat decodes numeric ('N') fields written by Clipper
+// tools that store raw binary values instead of ASCII digits: 4-byte fields are
+// read as little-endian float32 and 8-byte fields as little-endian float64.
+// Fields of other lengths are decoded as ASCII as usual.
+func WithClipperNumericFormat() Option {
+	return func(r *Reader) {
+		r.clipperNumeric = true
+	}
+}
+
+// WithDateCentury enables two-digit year expansion in ParseDate for legacy
+// files that store dates as YYMMDD, or as YYYYMMDD with a year below 100
+// (e.g. "00990115"). Years up to pivot map to the 2000s, later years to the
+// 1900s: with a pivot of 30, "300101" is 2030-01-01 and "990115" is 1999-01-15.
+func WithDateCentury(pivot int) Option {
+	return func(r *Reader) {
+		r.datePivot = pivot
+	}
+}
+
+// WithContext makes Read() fail with the context's error once ctx is
+// cancelled, which also ends iteration with Next(). The context is checked
+// before every record; see WithContextCheckInterval to check less often.
+func WithContext(ctx context.Context) Option {
+	return func(r *Reader) {
+		r.ctx = ctx
+	}
+}
+
+// WithContextCheckInterval makes a reader created with WithContext check the
+// context only every n records instead of on every Read(). For CPU-bound
+// processing an interval of around 100 makes the overhead negligible while
+// still reacting to cancellation quickly. The default is 1.
+func WithContextCheckInterval(n uint32) Option {
+	return func(r *Reader) {
+		r.ctxInterval = max(n, 1)
+	}
+}
+
+// WithIgnoreDecodeErrors makes character decode failures explicit: the raw
+// bytes are returned as the value (possibly invalid UTF-8) and a warning
+// naming the field is recorded in Warnings(). Without it, the raw bytes are
+// returned silently.
+func WithIgnoreDecodeErrors() Option {
+	return func(r *Reader) {
+		r.ignoreDecodeErrors = true
+	}
+}
+
+// TrimMode controls how padding spaces are removed from the values of
+// character fields and fields of unknown type.
+type TrimMode int
+
+const (
+	TrimBoth     TrimMode = iota // remove leading and trailing spaces (default)
+	TrimTrailing                 // remove trailing spaces only
+	TrimLeading                  // remove leading spaces only
+	TrimNone                     // keep values as stored
+)
+
+// WithTrimMode sets how padding is removed from character values, e.g.
+// TrimTrailing to keep the leading spaces of right-aligned values.
+// Numeric, date and logical values are always trimmed. With TrimNone or
+// TrimLeading, blank character fields are not reported as null.
+func WithTrimMode(mode TrimMode) Option {
+	return func(r *Reader) {
+		r.trimMode = mode
+	}
+}
+
+// WithNullString sets the value reported for null fields, for example "NULL"
+// or "\N" for loaders such as Postgres COPY. A field is null when it decodes
+// to an empty value: blank character, numeric and date fields, and logical
+// fields that are blank or '?'. The default is "".
+func WithNullString(s string) Option {
+	return func(r *Reader) {
+		r.nullString = s
+	}
+}
+
+// newReader returns a Reader with the default settings and opts applied,
+// before any source is attached.
+func newReader(opts ...Option) *Reader {
+	reader := &Reader{
+		size:        -1,
+		datePivot:   -1,
+		ctxInterval: 1,
+	}
+	for _, opt := range opts {
+		opt(reader)
+	}
+	return reader
+}
+
 // New creates a new DBF Reader from an io.Reader.
 //
 // If no encoding is specified via options, the reader will attempt to
@@ -190,15 +664,36 @@ func WithCP1252() Option {
 //	file, _ := os.Open("data.dbf")
 //	reader, err := dbf.New(file, dbf.WithCP866())
 func New(r io.Reader, opts ...Option) (*Reader, error) {
-	reader := &Reader{
-		reader: bufio.NewReader(r),
+	counter := &countingReader{r: r}
+	reader := newReader(opts...)
+	reader.reader = bufio.NewReader(counter)
+	reader.counter = counter
+
+	// remember random access capability for ReadAt/ReadRange
+	if ra, ok := r.(io.ReaderAt); ok {
+		reader.ra = ra
+	}
+	if wa, ok := r.(io.WriterAt); ok {
+		reader.wa = wa
 	}
 
-	// apply options
-	for _, opt := range opts {
-		opt(reader)
+	// determine the current position and the size of seekable sources
+	if s, ok := r.(io.Seeker); ok {
+		if pos, err := s.Seek(0, io.SeekCurrent); err == nil {
+			if end, err := s.Seek(0, io.SeekEnd); err == nil {
+				reader.size = end - pos
+			}
+			if _, err := s.Seek(pos, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("seek to start: %w", err)
+			}
+			reader.base = pos
+		}
 	}
 
+	// keep a copy of the raw header bytes while parsing it
+	var header bytes.Buffer
+	counter.r = io.TeeReader(r, &header)
+
 	// read file metadata (header)
 	if err := reader.readMetadata(); err != nil {
 		return nil, fmt.Errorf("read metadata: %w", err)
@@ -214,9 +709,83 @@ func New(r io.Reader, opts ...Option) (*Reader, error) {
 		return nil, fmt.Errorf("read fields: %w", err)
 	}
 
+	counter.r = r
+	reader.header = header.Bytes()[:min(header.Len(), int(reader.headerBytesNumber))]
+
+	if reader.aliases != nil {
+		reader.applyAliases()
+	}
+
+	if reader.fieldDecoders != nil {
+		if err := reader.resolveFieldDecoders(); err != nil {
+			return nil, fmt.Errorf("field encoding: %w", err)
+		}
+	}
+
+	if reader.autoAlign {
+		if computed := reader.computedRecordSize(); computed != reader.recordBytesNumber {
+			reader.warn("header declares record size %d, fields require %d; using %d", reader.recordBytesNumber, computed, computed)
+			reader.recordBytesNumber = computed
+		}
+	}
+
+	if reader.validateFieldLengths {
+		if computed := reader.computedRecordSize(); computed != reader.recordBytesNumber {
+			return nil, &FieldLengthMismatchError{Declared: reader.recordBytesNumber, Computed: computed}
+		}
+	}
+
+	if reader.countFromFile {
+		actual, ok := reader.recordCountFromSize()
+		if !ok {
+			return nil, fmt.Errorf("record count from file: %w", ErrNotSeekable)
+		}
+		if actual != reader.recordsCount {
+			reader.warn("header declares %d records, file contains %d", reader.recordsCount, actual)
+		}
+		reader.recordsCount = actual
+	} else if reader.acceptCountMismatch {
+		if actual, ok := reader.recordCountFromSize(); ok && actual != reader.recordsCount {
+			reader.warn("header declares %d records, file contains %d", reader.recordsCount, actual)
+			reader.recordsCount = min(reader.recordsCount, actual)
+		}
+	}
+
+	// opened last so that no earlier error leaks the file
+	if reader.memoPath != "" {
+		if err := reader.openMemo(reader.memoPath); err != nil {
+			return nil, fmt.Errorf("open memo file: %w", err)
+		}
+	}
+
 	return reader, nil
 }
 
+// NewFromReader creates a new DBF Reader from an io.Reader.
+// It is equivalent to New() and completes the NewFromFile/NewFromBytes
+// family of constructors.
+//
+// If r also implements io.ReaderAt (as *os.File and *bytes.Reader do),
+// random access methods such as ReadAt are available.
+//
+// Example:
+//
+//	file, _ := os.Open("data.dbf")
+//	reader, err := dbf.NewFromReader(file, dbf.WithCP866())
+func NewFromReader(r io.Reader, opts ...Option) (*Reader, error) {
+	return New(r, opts...)
+}
+
+// NewFromBytes creates a new DBF Reader from an in-memory DBF file.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("data.dbf")
+//	reader, err := dbf.NewFromBytes(data, dbf.WithCP866())
+func NewFromBytes(data []byte, opts ...Option) (*Reader, error) {
+	return New(bytes.NewReader(data), opts...)
+}
+
 // NewFromFile creates a new DBF Reader from a file path.
 // This is a convenience wrapper around New() for file-based reading.
 //
@@ -235,6 +804,105 @@ func NewFromFile(path string, opts ...Option) (*Reader, error) {
 		return nil, err
 	}
 
+	reader.file = file
+	reader.path = path
+
+	if reader.memo == nil && !reader.noAutoMemo && reader.expectsMemoFile() {
+		if memoPath := reader.memoFilePath(); memoPath != "" {
+			if err := reader.openMemo(memoPath); err != nil {
+				reader.warn("memo file %s cannot be read: %v", memoPath, err)
+			}
+		}
+	}
+
+	return reader, nil
+}
+
+// NewFromRangeReaderAt creates a new DBF Reader over a random-access source
+// of the given size, such as a remote object fetched with HTTP Range requests.
+//
+// The header is read sequentially from the start of the source, while ReadAt
+// and ReadRange fetch only the byte ranges of the requested records.
+//
+// Example of an HTTP-backed io.ReaderAt:
+//
+//	type httpReaderAt struct{ url string }
+//
+//	func (h httpReaderAt) ReadAt(p []byte, off int64) (int, error) {
+//		req, _ := http.NewRequest(http.MethodGet, h.url, nil)
+//		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1))
+//		resp, err := http.DefaultClient.Do(req)
+//		if err != nil {
+//			return 0, err
+//		}
+//		defer resp.Body.Close()
+//		return io.ReadFull(resp.Body, p)
+//	}
+//
+//	reader, err := dbf.NewFromRangeReaderAt(httpReaderAt{url}, size, dbf.WithCP866())
+func NewFromRangeReaderAt(ra io.ReaderAt, size int64, opts ...Option) (*Reader, error) {
+	return New(io.NewSectionReader(ra, 0, size), opts...)
+}
+
+// NewAt creates a new DBF Reader for a table embedded in a larger container,
+// occupying the size bytes of ra starting at offset. All offsets are relative
+// to the start of the table, and random access methods such as ReadAt stay
+// within that range.
+//
+// Example:
+//
+//	archive, _ := os.Open("backup.pak")
+//	reader, err := dbf.NewAt(archive, entry.Offset, entry.Size, dbf.WithCP866())
+func NewAt(ra io.ReaderAt, offset, size int64, opts ...Option) (*Reader, error) {
+	if offset < 0 || size < 0 {
+		return nil, fmt.Errorf("invalid range: offset %d, size %d", offset, size)
+	}
+	return New(io.NewSectionReader(ra, offset, size), opts...)
+}
+
+// NewFromGzip creates a new DBF Reader from a gzip-compressed file such as
+// data.dbf.gz. The content is sniffed, so an uncompressed DBF file is opened
+// as with NewFromFile.
+//
+// A gzip stream does not support random access: ReadAt, Rewind, Clone and
+// the other methods that need io.ReaderAt return ErrNotSeekable, and memo
+// files are not opened automatically.
+//
+// Example:
+//
+//	reader, err := dbf.NewFromGzip("archive/data.dbf.gz", dbf.WithCP866())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reader.Close()
+func NewFromGzip(path string, opts ...Option) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(file, magic); err != nil || magic[0] != 0x1F || magic[1] != 0x8B {
+		_ = file.Close()
+		return NewFromFile(path, opts...)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("seek to start: %w", err)
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("open gzip stream: %w", err)
+	}
+
+	reader, err := New(gz, opts...)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
 	reader.file = file
 	return reader, nil
 }
@@ -245,21 +913,235 @@ func (r *Reader) FileType() FileType {
 }
 
 // LastUpdate returns the date when the DBF file was last modified.
+// The header stores the year as a single byte counting from 1900, so only
+// dates from 1900 (byte 0) to 2155 (byte 255) can be represented; 2024 is
+// stored as 124.
 func (r *Reader) LastUpdate() time.Time {
 	return r.lastUpdate
 }
 
+// HasLastUpdate reports whether the header contains a valid last update date.
+// If it does not, LastUpdate returns the zero time.
+func (r *Reader) HasLastUpdate() bool {
+	return !r.lastUpdate.IsZero()
+}
+
 // RecordsCount returns the total number of records in the DBF file,
 // including deleted records.
 func (r *Reader) RecordsCount() uint32 {
 	return r.recordsCount
 }
 
+// UnknownFieldTypes returns the distinct field type bytes, in order of first
+// appearance, that are not explicitly supported by the decoder.
+// Values of such fields are decoded as character data.
+func (r *Reader) UnknownFieldTypes() []byte {
+	var unknown []byte
+	for _, field := range r.fields {
+		if isKnownFieldType(field.Type) || bytes.IndexByte(unknown, field.Type) >= 0 {
+			continue
+		}
+		unknown = append(unknown, field.Type)
+	}
+	return unknown
+}
+
+// Warnings returns non-fatal issues detected while reading the file,
+// such as an assumed encoding.
+func (r *Reader) Warnings() []string {
+	return r.warnings
+}
+
+// warn records a warning and passes it to the WithOnWarning callback, if any.
+func (r *Reader) warn(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	r.warnings = append(r.warnings, msg)
+	if r.onWarning != nil {
+		r.onWarning(msg)
+	}
+}
+
+// LDID returns the raw Language Driver ID byte (header offset 29),
+// regardless of whether it was used to select the encoding.
+func (r *Reader) LDID() byte {
+	return r.ldid
+}
+
+// TableFlags returns the table flags byte of dBASE IV and Visual FoxPro
+// headers (offset 28). It is 0 for formats that do not use it.
+func (r *Reader) TableFlags() byte {
+	return r.tableFlags
+}
+
+// HasCDXIndex reports whether the table flags indicate a structural .cdx index.
+func (r *Reader) HasCDXIndex() bool {
+	return r.tableFlags&0x01 != 0
+}
+
+// HasMemoFile reports whether the table flags indicate a memo file.
+func (r *Reader) HasMemoFile() bool {
+	return r.tableFlags&0x02 != 0
+}
+
+// HasMDXIndex reports whether a dBASE IV SQL table or system file has a
+// production .mdx index (header byte 28). The indexed fields are marked
+// with Field.Indexed. It is always false for other file types.
+func (r *Reader) HasMDXIndex() bool {
+	return isDBase4SQL(r.fileType) && r.tableFlags&0x01 != 0
+}
+
+// isDBase4SQL reports whether the file type is a dBASE IV SQL table or
+// system file, whose headers carry the production .mdx flag in byte 28 and
+// an index flag in byte 31 of every field descriptor.
+func isDBase4SQL(ft FileType) bool {
+	switch ft {
+	case dBASEIVTF, dBASEIVSF, dBASEIVTFMemo:
+		return true
+	}
+	return false
+}
+
+// IsDBCTable reports whether the table flags indicate that the table belongs
+// to a Visual FoxPro database container (see OpenDBC).
+func (r *Reader) IsDBCTable() bool {
+	return r.tableFlags&0x04 != 0
+}
+
+// isVisualFoxPro reports whether the file type belongs to Visual FoxPro.
+func (r *Reader) isVisualFoxPro() bool {
+	switch r.fileType {
+	case VisualFoxPro, VisualFoxProAI, VisualFoxProVarchar:
+		return true
+	}
+	return false
+}
+
+// IsEncrypted reports whether the dBASE IV encryption flag (header byte 15)
+// is set. Field values of encrypted tables cannot be decoded; a warning is
+// recorded when such a table is opened.
+func (r *Reader) IsEncrypted() bool {
+	return r.encrypted
+}
+
+// InTransaction reports whether the dBASE IV incomplete transaction flag
+// (header byte 14) is set, meaning a transaction was not completed.
+func (r *Reader) InTransaction() bool {
+	return r.inTransaction
+}
+
+// BytesRead returns the number of bytes consumed so far by sequential
+// reading (header and records), excluding data buffered ahead but not yet
+// parsed. It can be used to compute throughput during long imports.
+// Random access methods such as ReadAt are not counted.
+func (r *Reader) BytesRead() int64 {
+	if r.segments != nil {
+		var n int64
+		for _, segment := range r.segments {
+			n += segment.BytesRead()
+		}
+		return n
+	}
+	return r.counter.n - int64(r.reader.Buffered())
+}
+
+// HeaderSize returns the size of the header in bytes, including field
+// descriptors. Records start at this offset.
+func (r *Reader) HeaderSize() uint16 {
+	return r.headerBytesNumber
+}
+
+// RecordSize returns the size of a single record in bytes,
+// including the deletion flag.
+func (r *Reader) RecordSize() uint16 {
+	return r.recordBytesNumber
+}
+
+// RecordOffset returns the byte offset of the record with the given
+// zero-based index from the start of the DBF file.
+func (r *Reader) RecordOffset(index uint32) int64 {
+	return int64(r.headerBytesNumber) + int64(index)*int64(r.recordBytesNumber)
+}
+
+// FieldOffsetInFile returns the byte offset of the named field within the
+// record with the given zero-based index, counted from the start of the file.
+// Returns false if the index is out of range or the field does not exist.
+func (r *Reader) FieldOffsetInFile(index uint32, field string) (int64, bool) {
+	if index >= r.recordsCount {
+		return 0, false
+	}
+
+	offset, ok := r.fieldOffset(field)
+	if !ok {
+		return 0, false
+	}
+	return r.RecordOffset(index) + int64(offset), true
+}
+
+// field returns the definition of the named field.
+func (r *Reader) field(name string) (Field, bool) {
+	for _, field := range r.fields {
+		if field.Name == name || r.dataKey(field) == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// fieldOffset returns the offset of the named field within a record,
+// accounting for the leading deletion flag.
+func (r *Reader) fieldOffset(name string) (int, bool) {
+	offset := 1 // deletion flag
+	for _, field := range r.fields {
+		if field.Name == name {
+			return offset, true
+		}
+		offset += int(field.Length)
+	}
+	return 0, false
+}
+
 // Fields returns the field definitions for the DBF table.
 func (r *Reader) Fields() []Field {
 	return r.fields
 }
 
+// AliasedFields returns the field definitions with the names used as keys in
+// Record.Data, i.e. with aliases from WithFieldAliases applied. Without
+// aliases it is the same as Fields().
+func (r *Reader) AliasedFields() []Field {
+	if r.aliasedFields == nil {
+		return r.fields
+	}
+	return r.aliasedFields
+}
+
+// applyAliases builds the aliased field list and warns about aliases that
+// do not match any field.
+func (r *Reader) applyAliases() {
+	r.aliasedFields = make([]Field, len(r.fields))
+	for i, field := range r.fields {
+		if alias, ok := r.aliases[field.Name]; ok {
+			field.Name = alias
+		}
+		r.aliasedFields[i] = field
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(r.aliases)) {
+		if !slices.ContainsFunc(r.fields, func(f Field) bool { return f.Name == name }) {
+			r.warn("alias for unknown field %s ignored", name)
+		}
+	}
+}
+
+// dataKey returns the key under which the value of field is stored in
+// Record.Data.
+func (r *Reader) dataKey(field Field) string {
+	if alias, ok := r.aliases[field.Name]; ok {
+		return alias
+	}
+	return field.Name
+}
+
 // FieldsCount returns the number of fields in the DBF table.
 func (r *Reader) FieldsCount() int {
 	return len(r.fields)
@@ -275,7 +1157,10 @@ func (r *Reader) readMetadata() error {
 
 	fileType := FileType(b)
 	if !isValidFileType(fileType) {
-		return fmt.Errorf("unknown file type: 0x%02X", b)
+		if !r.permissiveFileType {
+			return fmt.Errorf("unknown file type: 0x%02X", b)
+		}
+		r.warn("unknown file type 0x%02X, assuming dBASE III layout", b)
 	}
 	r.fileType = fileType
 
@@ -285,13 +1170,16 @@ func (r *Reader) readMetadata() error {
 		return fmt.Errorf("read last update date: %w", err)
 	}
 
-	r.lastUpdate = time.Date(
-		int(dateBytes[0])+1900,
-		time.Month(dateBytes[1]),
-		int(dateBytes[2]),
-		0, 0, 0, 0,
-		time.UTC,
-	)
+	// the year is an offset from 1900: 0-99 are 1900-1999, 100-199 are 2000-2099.
+	// time.Date normalizes invalid values (month 13 becomes next January),
+	// so check the ranges first to avoid reporting a plausible but wrong date
+	year, month, day := int(dateBytes[0])+1900, time.Month(dateBytes[1]), int(dateBytes[2])
+	lastUpdate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if month < time.January || month > time.December || day < 1 || day > 31 || lastUpdate.Day() != day {
+		r.warn("invalid last update date in header: %02X %02X %02X", dateBytes[0], dateBytes[1], dateBytes[2])
+	} else {
+		r.lastUpdate = lastUpdate
+	}
 
 	// read record count (4 bytes, little-endian)
 	recordsBytes := make([]byte, 4)
@@ -306,7 +1194,6 @@ func (r *Reader) readMetadata() error {
 		return fmt.Errorf("read header size: %w", err)
 	}
 	r.headerBytesNumber = binary.LittleEndian.Uint16(headerBytes)
-	r.fieldsCount = (r.headerBytesNumber - metadataLength) / fieldLength
 
 	// read record size (2 bytes, little-endian)
 	recordBytes := make([]byte, 2)
@@ -321,49 +1208,121 @@ func (r *Reader) readMetadata() error {
 		return fmt.Errorf("read reserved bytes: %w", err)
 	}
 
-	// byte 29 (index 17) contains the Language Driver ID
+	// bytes 14 and 15 (index 2 and 3) are the dBASE IV transaction and encryption flags
+	r.inTransaction = reserved[2] != 0
+	r.encrypted = reserved[3] != 0
+	if r.encrypted {
+		r.warn("table is encrypted, field values will not decode correctly")
+	}
+
+	// byte 28 (index 16) contains the table flags, byte 29 (index 17) the Language Driver ID
+	r.tableFlags = reserved[16]
+	r.ldid = reserved[17]
+
 	// try to auto-detect encoding if not explicitly set
 	if r.decoder == nil {
-		languageDriverID := reserved[17]
-		r.decoder = getDecoderByLDID(languageDriverID)
+		switch {
+		case r.ldid == 0x00 && r.vfpDefaultEncoding && r.isVisualFoxPro():
+			r.decoder = charmap.Windows1252.NewDecoder()
+			r.warn("no language driver specified (LDID 0x00) in Visual FoxPro table, assuming Windows-1252")
+		case r.ldid == 0x00:
+			r.decoder = getDecoderByLDID(r.ldid)
+			r.warn("no language driver specified (LDID 0x00), assuming ISO-8859-1")
+		default:
+			r.decoder = getDecoderByLDID(r.ldid)
+		}
+	}
+
+	if r.fileType == dBASE7 || r.fileType == dBASE7Memo {
+		r.dBASE7Mode = true
+	}
+
+	if !r.dBASE7Mode {
+		r.fieldsCount = (r.headerBytesNumber - metadataLength) / fieldLength
+		return nil
 	}
 
+	// dBASE 7: language driver name (32 bytes) and reserved (4 bytes)
+	if _, err := r.reader.Discard(int(dBASE7MetadataLength - metadataLength)); err != nil {
+		return fmt.Errorf("read dBASE 7 header: %w", err)
+	}
+	if r.headerBytesNumber < dBASE7MetadataLength {
+		return fmt.Errorf("invalid dBASE 7 header size: %d", r.headerBytesNumber)
+	}
+	r.fieldsCount = (r.headerBytesNumber - dBASE7MetadataLength) / dBASE7FieldLength
+
 	return nil
 }
 
 // readFields reads all field descriptors from the DBF header.
+//
+// Descriptors are read up to the 0x0D terminator; any bytes between the
+// terminator and the declared header size (such as the 263-byte backlink
+// of Visual FoxPro tables) are skipped.
 func (r *Reader) readFields() error {
 	r.fields = make([]Field, 0, r.fieldsCount)
 
-	for i := uint16(0); i < r.fieldsCount; i++ {
+	// the header size gives an upper bound for the number of fields
+	for {
+		next, err := r.reader.Peek(1)
+		if err != nil {
+			return fmt.Errorf("read terminator after field %d: %w", len(r.fields), truncated(err))
+		}
+		if next[0] == 0x0D {
+			break
+		}
+		if len(r.fields) == int(r.fieldsCount) {
+			return fmt.Errorf("invalid field descriptor terminator: 0x%02X, expected 0x0D", next[0])
+		}
+
 		field, err := r.readField()
 		if err != nil {
-			return fmt.Errorf("read field %d: %w", i, err)
+			return fmt.Errorf("read field %d: %w", len(r.fields), err)
 		}
 		r.fields = append(r.fields, field)
 	}
 
-	// read field descriptor terminator (0x0D)
-	terminator, err := r.reader.ReadByte()
-	if err != nil {
+	// consume field descriptor terminator (0x0D)
+	if _, err := r.reader.Discard(1); err != nil {
 		return fmt.Errorf("read terminator: %w", err)
 	}
-	if terminator != 0x0D {
-		return fmt.Errorf("invalid field descriptor terminator: 0x%02X, expected 0x0D", terminator)
+	r.fieldsCount = uint16(len(r.fields))
+
+	// skip extra header bytes so that records start at the declared header size
+	start, descriptorLength := r.descriptorLayout()
+	consumed := start + len(r.fields)*descriptorLength + 1
+	if extra := int(r.headerBytesNumber) - consumed; extra > 0 {
+		if _, err := r.reader.Discard(extra); err != nil {
+			return fmt.Errorf("skip %d header bytes after terminator: %w", extra, err)
+		}
 	}
 
 	return nil
 }
 
-// readField reads a single 32-byte field descriptor.
+// truncated marks an unexpected end of file in the header with ErrTruncated.
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrTruncated, err)
+	}
+	return err
+}
+
+// readField reads a single 32-byte field descriptor
+// (48 bytes with a 32-byte field name in dBASE 7 mode).
 func (r *Reader) readField() (Field, error) {
-	fieldBytes := make([]byte, 32)
+	descriptorLength, nameLength := fieldLength, 11
+	if r.dBASE7Mode {
+		descriptorLength, nameLength = dBASE7FieldLength, 32
+	}
+
+	fieldBytes := make([]byte, descriptorLength)
 	if _, err := io.ReadFull(r.reader, fieldBytes); err != nil {
-		return Field{}, fmt.Errorf("read field bytes: %w", err)
+		return Field{}, fmt.Errorf("read field bytes: %w", truncated(err))
 	}
 
-	// field name (11 bytes, null-terminated)
-	nameBytes := bytes.TrimRight(fieldBytes[0:11], "\x00")
+	// field name (null-terminated)
+	nameBytes := bytes.TrimRight(fieldBytes[0:nameLength], "\x00")
 	decodedName, err := r.decoder.Bytes(nameBytes)
 	if err != nil {
 		// if decoding fails, use the raw bytes
@@ -371,48 +1330,76 @@ func (r *Reader) readField() (Field, error) {
 	}
 
 	field := Field{
-		Name:          string(decodedName),
-		Type:          fieldBytes[11],
-		MemoryAddress: binary.LittleEndian.Uint32(fieldBytes[12:16]),
-		Length:        fieldBytes[16],
-		DecimalCount:  fieldBytes[17],
+		Name:    string(decodedName),
+		RawName: string(decodedName),
+	}
+
+	if r.dBASE7Mode {
+		// type, length and decimal count follow the name; no memory address
+		field.Type = fieldBytes[32]
+		field.Length = fieldBytes[33]
+		field.DecimalCount = fieldBytes[34]
+	} else {
+		field.Type = fieldBytes[11]
+		field.MemoryAddress = binary.LittleEndian.Uint32(fieldBytes[12:16])
+		field.Length = fieldBytes[16]
+		field.DecimalCount = fieldBytes[17]
+		if isDBase4SQL(r.fileType) {
+			field.Indexed = fieldBytes[31] != 0
+		}
+	}
+
+	if r.upperCaseNames {
+		field.Name = strings.ToUpper(field.Name)
 	}
 
 	return field, nil
 }
 
+// recordCountFromSize returns the number of complete records that fit between
+// the end of the header and the end of the source.
+// Returns false if the source size is unknown.
+func (r *Reader) recordCountFromSize() (uint32, bool) {
+	if r.size < 0 || r.recordBytesNumber == 0 {
+		return 0, false
+	}
+
+	dataSize := max(r.size-int64(r.headerBytesNumber), 0)
+	return uint32(min(dataSize/int64(r.recordBytesNumber), math.MaxUint32)), true
+}
+
+// computedRecordSize returns the record size derived from the field
+// descriptors: the deletion flag plus the length of every field.
+func (r *Reader) computedRecordSize() uint16 {
+	size := uint16(1)
+	for _, field := range r.fields {
+		size += uint16(field.Length)
+	}
+	return size
+}
+
+// isKnownFieldType reports whether decodeFieldValue explicitly handles the field type.
+func isKnownFieldType(t byte) bool {
+	switch t {
+	case 'C', 'N', 'F', 'D', 'L', 'M', 'G', 'I':
+		return true
+	default:
+		return false
+	}
+}
+
 // isValidFileType checks if the given file type is recognized.
 func isValidFileType(ft FileType) bool {
 	switch ft {
 	case FoxBASE, FoxBASEPlusNoMemo, VisualFoxPro, VisualFoxProAI,
 		VisualFoxProVarchar, dBASEIVTF, dBASEIVSF, FoxBASEPlusMemo,
-		dBASEIVMemo, dBASEIVTFMemo, FoxPro2, HiPerSix:
+		dBASEIVMemo, dBASEIVTFMemo, FoxPro2, HiPerSix, dBASE7, dBASE7Memo:
 		return true
 	default:
 		return false
 	}
 }
 
-// getDecoderByLDID returns an appropriate text decoder based on the
-// Language Driver ID byte from the DBF header.
-// Returns nil if the Language Driver ID is not recognized.
-func getDecoderByLDID(ldid byte) *encoding.Decoder {
-	switch ldid {
-	case 0x26: // CP866 (Russian MS-DOS)
-		return charmap.CodePage866.NewDecoder()
-	case 0x64, 0x65, 0xC9: // CP1251 (Russian Windows)
-		return charmap.Windows1251.NewDecoder()
-	case 0x03: // CP1252 (Windows ANSI)
-		return charmap.Windows1252.NewDecoder()
-	case 0x01: // CP437 (US MS-DOS)
-		return charmap.CodePage437.NewDecoder()
-	case 0x02: // CP850 (International MS-DOS)
-		return charmap.CodePage850.NewDecoder()
-	default:
-		return nil
-	}
-}
-
 // String returns a string representation of the Reader for debugging.
 func (r *Reader) String() string {
 	return fmt.Sprintf(
@@ -432,10 +1419,214 @@ func (r *Reader) String() string {
 	)
 }
 
+// countingReader wraps an io.Reader and counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 // Record represents a single record from the DBF file.
 type Record struct {
 	Deleted bool              // true if the record is marked as deleted
 	Data    map[string]string // field values indexed by field name
+
+	fields []Field           // schema of the reader that produced the record
+	folded map[string]string // lower-case field name -> key in Data, built by Get
+	raw    map[string]string // untrimmed values, set by ReadWithRaw
+}
+
+// RawString returns the value of the named field as stored, with its padding,
+// for records read with ReadWithRaw. It returns an empty string for other
+// records and unknown fields.
+func (rec *Record) RawString(field string) string {
+	return rec.raw[field]
+}
+
+// Equal reports whether two records have the same deletion flag and field values.
+func (rec *Record) Equal(other *Record) bool {
+	if rec == nil || other == nil {
+		return rec == other
+	}
+	return rec.Deleted == other.Deleted && maps.Equal(rec.Data, other.Data)
+}
+
+// RuneLen returns the number of characters in the decoded value of a field,
+// which may differ from the field's byte Length for multi-byte encodings.
+// Returns 0 if the field does not exist.
+func (rec *Record) RuneLen(field string) int {
+	return utf8.RuneCountInString(rec.Data[field])
+}
+
+// Get returns the value of a field looked up case-insensitively, so that
+// Get("name") finds "NAME". The lookup index is built on first use and is not
+// updated if keys are added to Data afterwards; Get is not safe for
+// concurrent use on the same record.
+func (rec *Record) Get(name string) (string, bool) {
+	if value, ok := rec.Data[name]; ok {
+		return value, true
+	}
+
+	if rec.folded == nil {
+		rec.folded = make(map[string]string, len(rec.Data))
+		for key := range rec.Data {
+			rec.folded[strings.ToLower(key)] = key
+		}
+	}
+
+	key, ok := rec.folded[strings.ToLower(name)]
+	if !ok {
+		return "", false
+	}
+	value, ok := rec.Data[key]
+	return value, ok
+}
+
+// Has reports whether name is a key in Data. Unlike a plain map lookup
+// returning "", it tells a missing field apart from an empty value.
+func (rec *Record) Has(name string) bool {
+	_, ok := rec.Data[name]
+	return ok
+}
+
+// GetOr returns the value of the named field, or defaultValue if Data has
+// no such key. An existing field with an empty value returns "".
+//
+// Example:
+//
+//	region := record.GetOr("REGION", "unknown")
+func (rec *Record) GetOr(name, defaultValue string) string {
+	if value, ok := rec.Data[name]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+// textEscaper escapes field values in MarshalText output.
+var textEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// MarshalText implements encoding.TextMarshaler. It returns the field values
+// separated by tabs, in field declaration order for records returned by a
+// Reader, or in alphabetical field name order otherwise. Backslashes, tabs
+// and line breaks in values are escaped as \\, \t, \n and \r, as in the
+// Postgres COPY text format. The deletion flag is not included.
+func (rec *Record) MarshalText() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, name := range rec.fieldNames() {
+		if i > 0 {
+			buf.WriteByte('\t')
+		}
+		buf.WriteString(textEscaper.Replace(rec.Data[name]))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fieldNames returns the keys of Data in field declaration order for records
+// returned by a Reader, or sorted by name for records built by hand.
+func (rec *Record) fieldNames() []string {
+	if rec.fields == nil {
+		return slices.Sorted(maps.Keys(rec.Data))
+	}
+
+	names := make([]string, len(rec.fields))
+	for i, field := range rec.fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// String implements fmt.Stringer. It formats the record on one line as
+// NAME=value pairs in field order, prefixed with [*] if the record is
+// deleted. Values that are empty or contain commas or spaces are quoted.
+//
+// Example output:
+//
+//	[*] NAME="Jane Smith", AGE=31
+func (rec *Record) String() string {
+	var buf strings.Builder
+	if rec.Deleted {
+		buf.WriteString("[*] ")
+	}
+
+	for i, name := range rec.fieldNames() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		value := rec.Data[name]
+		if value == "" || strings.ContainsAny(value, ", ") {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+	}
+
+	return buf.String()
+}
+
+// PrettyString formats the record on multiple lines, one field per line
+// with the names padded to the longest name, each line prefixed with
+// indent. Deleted records start with a [*] line.
+//
+// Example output:
+//
+//	NAME      : Jane Smith
+//	AGE       : 31
+//	BIRTHDATE : 19930704
+func (rec *Record) PrettyString(indent string) string {
+	names := rec.fieldNames()
+
+	width := 0
+	for _, name := range names {
+		width = max(width, utf8.RuneCountInString(name))
+	}
+
+	var buf strings.Builder
+	if rec.Deleted {
+		buf.WriteString(indent + "[*]\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s%-*s : %s\n", indent, width, name, rec.Data[name])
+	}
+
+	return buf.String()
+}
+
+// DiffRecords returns the fields whose values differ between a and b,
+// mapped to their old (a) and new (b) values. Fields present in only one
+// record are reported with an empty value for the other; a nil record is
+// treated as a record without fields.
+// The deletion flag is not compared.
+func DiffRecords(a, b *Record) map[string][2]string {
+	diff := make(map[string][2]string)
+
+	var oldData, newData map[string]string
+	if a != nil {
+		oldData = a.Data
+	}
+	if b != nil {
+		newData = b.Data
+	}
+
+	for name, oldValue := range oldData {
+		if newValue, ok := newData[name]; !ok || newValue != oldValue {
+			diff[name] = [2]string{oldValue, newValue}
+		}
+	}
+	for name, newValue := range newData {
+		if _, ok := oldData[name]; !ok {
+			diff[name] = [2]string{"", newValue}
+		}
+	}
+
+	return diff
 }
 
 // Next advances to the next record in the DBF file.
@@ -455,69 +1646,826 @@ type Record struct {
 //		log.Fatal(err)
 //	}
 func (r *Reader) Next() bool {
-	if r.currentRecord >= r.recordsCount {
-		return false
+	if r.segments != nil {
+		return r.nextSegment()
 	}
 
-	r.currentRecord++
-	return r.err == nil
-}
+	for r.err == nil && r.hasMoreRecords() {
+		if r.skipDeleted {
+			deleted, err := r.peekDeleted()
+			if err != nil {
+				r.err = err
+				return false
+			}
+			if deleted {
+				if err := r.skipRecord(); err != nil {
+					r.err = fmt.Errorf("skip deleted record: %w", err)
+					return false
+				}
+				continue
+			}
+		}
 
-// Read reads the current record. Must be called after a successful Next() call.
-// Returns an error if reading fails or if called without a prior Next() call.
-func (r *Reader) Read() (*Record, error) {
-	if r.err != nil {
-		return nil, r.err
+		r.currentRecord++
+		return true
 	}
 
-	// read the entire record
-	recordBytes := make([]byte, r.recordBytesNumber)
-	if _, err := io.ReadFull(r.reader, recordBytes); err != nil {
-		r.err = fmt.Errorf("read record bytes: %w", err)
-		return nil, r.err
+	return false
+}
+
+// hasMoreRecords reports whether another record follows, reading past the
+// header count with WithReadToEOF.
+func (r *Reader) hasMoreRecords() bool {
+	return r.currentRecord < r.recordsCount || r.readToEOF && r.moreRecords()
+}
+
+// moreRecords reports whether a complete record follows the declared
+// records and, if so, counts it in recordsCount. If the size of the source
+// is unknown and the record does not fit in the read buffer, the record is
+// read ahead into r.pending.
+func (r *Reader) moreRecords() bool {
+	flag, err := r.reader.Peek(1)
+	if err != nil || flag[0] == 0x1A {
+		r.readToEOF = false
+		return false
 	}
 
-	record := &Record{
-		Deleted: recordBytes[0] == 0x2A, // '*' marks deleted records
-		Data:    make(map[string]string, len(r.fields)),
+	size := int(r.recordBytesNumber)
+	var complete bool
+	switch {
+	case r.size >= 0:
+		complete = r.RecordOffset(r.currentRecord)+int64(size) <= r.size
+	case size <= r.reader.Size():
+		data, _ := r.reader.Peek(size)
+		complete = len(data) == size
+	default:
+		record := make([]byte, size)
+		if _, err := io.ReadFull(r.reader, record); err == nil {
+			r.pending = record
+			complete = true
+		}
+	}
+	if !complete {
+		r.warn("partial record after record %d ignored", r.recordsCount)
+		r.readToEOF = false
+		return false
 	}
 
-	// parse individual fields
-	offset := 1 // skip deletion flag
-	for _, field := range r.fields {
-		fieldData := recordBytes[offset : offset+int(field.Length)]
-		offset += int(field.Length)
+	if r.extraRecords == 0 {
+		r.warn("header declares %d records, reading past it", r.recordsCount)
+	}
+	r.extraRecords++
+	r.recordsCount++
+	return true
+}
 
-		// decode field value
-		value, err := r.decodeFieldValue(field, fieldData)
-		if err != nil {
-			r.err = fmt.Errorf("decode field %s: %w", field.Name, err)
-			return nil, r.err
-		}
+// skipRecord moves past the next record without decoding it. If the record
+// is not fully buffered and the source implements io.ReaderAt, reading is
+// repositioned after it so that its bytes are never read.
+func (r *Reader) skipRecord() error {
+	if r.pending != nil {
+		r.pending = nil
+		r.currentRecord++
+		return nil
+	}
+	if r.ra != nil && r.reader.Buffered() < int(r.recordBytesNumber) {
+		return r.seekRecord(r.currentRecord + 1)
+	}
 
-		record.Data[field.Name] = value
+	if _, err := r.reader.Discard(int(r.recordBytesNumber)); err != nil {
+		return err
 	}
+	r.currentRecord++
+	return nil
+}
 
-	return record, nil
+// peekDeleted reports whether the next record is marked as deleted
+// without consuming it.
+func (r *Reader) peekDeleted() (bool, error) {
+	if r.pending != nil {
+		return r.pending[0] == 0x2A, nil
+	}
+	flag, err := r.reader.Peek(1)
+	if err != nil {
+		return false, fmt.Errorf("read deletion flag: %w", err)
+	}
+	return flag[0] == 0x2A, nil
 }
 
-// ReadAll reads all records from the DBF file into memory.
-// This is convenient for small files but may consume significant memory for large files.
-// For large files, consider using Next()/Read() for streaming access.
+// SkipRecords advances the reader past the next n records without decoding
+// them, e.g. to resume an interrupted batch job. It must be called between
+// records (not between Next() and Read()). If the source implements
+// io.ReaderAt the reader is repositioned directly; otherwise the record bytes
+// are read and discarded.
+// Returns ErrOutOfRange if fewer than n records remain.
 //
 // Example:
 //
-//	records, err := reader.ReadAll()
-//	if err != nil {
+//	if err := reader.SkipRecords(lastProcessed); err != nil {
 //		log.Fatal(err)
 //	}
-//	for _, record := range records {
-//		fmt.Println(record.Data["NAME"])
+//	for reader.Next() {
+//		...
 //	}
-func (r *Reader) ReadAll() ([]*Record, error) {
-	records := make([]*Record, 0, r.recordsCount)
+func (r *Reader) SkipRecords(n uint32) error {
+	if r.err != nil {
+		return r.err
+	}
+	if n > r.recordsCount-r.currentRecord {
+		return fmt.Errorf("skip %d records: %w", n, ErrOutOfRange)
+	}
 
-	for r.Next() {
+	if r.segments != nil {
+		remaining := n
+		for remaining > 0 {
+			segment := r.segments[r.segment]
+			k := min(remaining, segment.recordsCount-segment.currentRecord)
+			if err := segment.SkipRecords(k); err != nil {
+				r.err = fmt.Errorf("%s: %w", segment.path, err)
+				return r.err
+			}
+			r.currentRecord += k
+			remaining -= k
+			if remaining > 0 {
+				r.segment++
+			}
+		}
+		return nil
+	}
+
+	if r.ra != nil {
+		return r.seekRecord(r.currentRecord + n)
+	}
+
+	for i := uint32(0); i < n; i++ {
+		if _, err := r.reader.Discard(int(r.recordBytesNumber)); err != nil {
+			r.err = fmt.Errorf("skip record %d: %w", r.currentRecord, err)
+			return r.err
+		}
+		r.currentRecord++
+	}
+
+	return nil
+}
+
+// Read reads the current record. Must be called after a successful Next() call.
+// Returns an error if reading fails or if called without a prior Next() call.
+func (r *Reader) Read() (*Record, error) {
+	return r.read(false)
+}
+
+// ReadWithRaw reads the current record like Read and additionally keeps
+// the decoded but untrimmed value of every field, available through
+// Record.RawString. Use it to tell space padding apart from spaces that are
+// part of the value.
+//
+// Example:
+//
+//	for reader.Next() {
+//		record, err := reader.ReadWithRaw()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Printf("%q stored as %q\n", record.Data["NAME"], record.RawString("NAME"))
+//	}
+func (r *Reader) ReadWithRaw() (*Record, error) {
+	return r.read(true)
+}
+
+// read reads the current record, keeping untrimmed values if withRaw is set.
+func (r *Reader) read(withRaw bool) (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if err := r.checkCancelled(); err != nil {
+		return nil, err
+	}
+
+	if r.segments != nil {
+		return r.readSegment(withRaw)
+	}
+
+	recordBytes, err := r.readRecordBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.parseRecord(recordBytes)
+	if withRaw {
+		r.parseRaw(record, recordBytes)
+	}
+	if err != nil {
+		if r.partialRecords {
+			// keep iterating; the caller decides what to do with the record
+			return record, err
+		}
+		r.err = err
+		return nil, r.err
+	}
+
+	return record, nil
+}
+
+// checkCancelled returns an error if the context set with WithContext is
+// done. The context is checked every ctxInterval records.
+func (r *Reader) checkCancelled() error {
+	if r.ctx != nil && r.contextCheckDue(r.currentRecord) {
+		if err := r.ctx.Err(); err != nil {
+			r.err = fmt.Errorf("read cancelled: %w", err)
+			return r.err
+		}
+	}
+	return nil
+}
+
+// readRecordBytes reads the entire current record. With WithPartialRecords,
+// a record cut short by the end of the file is returned as far as it goes
+// and ends iteration; its missing fields are reported by parseRecord.
+func (r *Reader) readRecordBytes() ([]byte, error) {
+	if r.pending != nil {
+		recordBytes := r.pending
+		r.pending = nil
+		return recordBytes, nil
+	}
+	recordBytes := make([]byte, r.recordBytesNumber)
+	n, err := io.ReadFull(r.reader, recordBytes)
+	if err != nil {
+		if r.partialRecords && errors.Is(err, io.ErrUnexpectedEOF) {
+			r.warn("record %d truncated at %d of %d bytes", r.currentRecord-1, n, r.recordBytesNumber)
+			r.recordsCount = r.currentRecord
+			return recordBytes[:n], nil
+		}
+		r.err = fmt.Errorf("read record bytes: %w", err)
+		return nil, r.err
+	}
+	return recordBytes, nil
+}
+
+// NextRecord advances to the next record and reads it in one call.
+// It returns ok=false and a nil error at the end of the file, and ok=false
+// with an error if the file is truncated or a record cannot be read.
+// With WithPartialRecords, a field decode failure returns the partial record
+// with ok=true together with the *FieldError.
+//
+// Example:
+//
+//	for {
+//		record, ok, err := reader.NextRecord()
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		if !ok {
+//			break
+//		}
+//		// process record
+//	}
+func (r *Reader) NextRecord() (*Record, bool, error) {
+	if !r.Next() {
+		return nil, false, r.Err()
+	}
+
+	record, err := r.Read()
+	if record == nil {
+		return nil, false, err
+	}
+
+	return record, true, err
+}
+
+// ReadAt reads the record at the given zero-based index without affecting
+// the position used by Next()/Read().
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) ReadAt(index uint32) (*Record, error) {
+	records, err := r.ReadRange(index, 1)
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+// ReadNthRecord reads the record with the given 1-based record number, as
+// returned by FoxPro's RECNO(), and positions the reader after it, so that
+// Next()/Read() continue with record n+1.
+// Returns ErrOutOfRange if n is 0 or greater than RecordsCount(), and
+// ErrNotSeekable if the source does not implement io.ReaderAt.
+//
+// Example:
+//
+//	record, err := reader.ReadNthRecord(recno) // recno entered by the user
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for reader.Next() { // records recno+1, recno+2, ...
+//		...
+//	}
+func (r *Reader) ReadNthRecord(n uint32) (*Record, error) {
+	if n == 0 || n > r.recordsCount {
+		return nil, fmt.Errorf("read record %d: %w", n, ErrOutOfRange)
+	}
+
+	record, err := r.ReadAt(n - 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.seekRecord(n); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ReadRange reads count consecutive records starting at the given zero-based
+// index using a single ReadAt call on the underlying source. It does not
+// affect the position used by Next()/Read().
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) ReadRange(start, count uint32) ([]*Record, error) {
+	if r.ra == nil {
+		return nil, ErrNotSeekable
+	}
+	if start >= r.recordsCount || count > r.recordsCount-start {
+		return nil, fmt.Errorf("read range [%d, %d): %w", start, uint64(start)+uint64(count), ErrOutOfRange)
+	}
+
+	size := int(r.recordBytesNumber)
+	data := make([]byte, int(count)*size)
+	offset := r.base + r.RecordOffset(start)
+	// io.ReaderAt may return io.EOF together with a full read at the end of the source
+	if n, err := r.ra.ReadAt(data, offset); err != nil && (err != io.EOF || n < len(data)) {
+		return nil, fmt.Errorf("read records at offset %d: %w", offset, err)
+	}
+
+	records := make([]*Record, 0, count)
+	for i := 0; i < int(count); i++ {
+		record, err := r.parseRecord(data[i*size : (i+1)*size])
+		if err != nil {
+			return records, fmt.Errorf("record %d: %w", start+uint32(i), err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Recover reads the record at the given zero-based index regardless of its
+// deletion flag, so soft-deleted records can be retrieved even when the
+// reader was created with WithSkipDeleted. The returned record has Deleted
+// set according to the flag in the file.
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) Recover(index uint32) (*Record, error) {
+	return r.ReadAt(index)
+}
+
+// RecoverAll returns all records marked as deleted, in file order.
+// It does not affect the position used by Next()/Read().
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) RecoverAll() ([]*Record, error) {
+	var indexes []uint32
+	if err := r.scanDeletionFlags(func(index uint32, deleted bool) {
+		if deleted {
+			indexes = append(indexes, index)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	records := make([]*Record, 0, len(indexes))
+	for _, index := range indexes {
+		record, err := r.ReadAt(index)
+		if err != nil {
+			return records, fmt.Errorf("recover record %d: %w", index, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// MarkDeleted marks the record with the given zero-based index as deleted by
+// writing the '*' deletion flag in place, the way FoxPro soft-deletes records.
+//
+// The source must implement io.WriterAt and be opened for writing, e.g. a
+// file opened with os.OpenFile(path, os.O_RDWR, 0) and passed to New.
+// Records already buffered for Next()/Read() are not affected.
+func (r *Reader) MarkDeleted(index uint32) error {
+	return r.writeDeletionFlag(index, 0x2A)
+}
+
+// Undelete clears the deletion flag of the record with the given zero-based
+// index in place. See MarkDeleted for requirements.
+func (r *Reader) Undelete(index uint32) error {
+	return r.writeDeletionFlag(index, 0x20)
+}
+
+// writeDeletionFlag writes the deletion flag byte of a record in place.
+func (r *Reader) writeDeletionFlag(index uint32, flag byte) error {
+	if r.wa == nil {
+		return ErrReadOnly
+	}
+	if index >= r.recordsCount {
+		return fmt.Errorf("record %d: %w", index, ErrOutOfRange)
+	}
+
+	offset := r.base + r.RecordOffset(index)
+	if _, err := r.wa.WriteAt([]byte{flag}, offset); err != nil {
+		return fmt.Errorf("write deletion flag at offset %d: %w", offset, err)
+	}
+	return nil
+}
+
+// DBFStat contains summary information about a DBF file.
+type DBFStat struct {
+	RecordCount  uint32 // records declared in the header, including deleted ones
+	ActiveCount  uint32 // records not marked as deleted
+	DeletedCount uint32 // records marked as deleted
+	FileSize     int64  // size of the DBF file in bytes, -1 if unknown
+	FieldCount   int    // number of fields
+	RecordSize   uint16 // size of a record in bytes
+	HasMemoFile  bool   // a memo file is attached or a sibling .fpt or .dbt file exists
+}
+
+// Stat returns summary information about the file without decoding records.
+//
+// When the source implements io.ReaderAt, ActiveCount and DeletedCount are
+// computed by reading only the deletion flag byte of each record; otherwise
+// both are zero. The position used by Next()/Read() is not affected.
+func (r *Reader) Stat() DBFStat {
+	stat := DBFStat{
+		RecordCount: r.recordsCount,
+		FileSize:    r.size,
+		FieldCount:  len(r.fields),
+		RecordSize:  r.recordBytesNumber,
+		HasMemoFile: r.memo != nil || (r.path != "" && Siblings(r.path).HasMemo),
+	}
+
+	_ = r.scanDeletionFlags(func(_ uint32, deleted bool) {
+		if deleted {
+			stat.DeletedCount++
+		} else {
+			stat.ActiveCount++
+		}
+	})
+
+	return stat
+}
+
+// Layout describes the geometry of a DBF file.
+type Layout struct {
+	HeaderSize      uint16 // header size in bytes, including field descriptors
+	RecordSize      uint16 // size of a record in bytes, including the deletion flag
+	DataStartOffset int64  // offset of the first record from the start of the file
+	DataSize        int64  // size of all records: record count times record size
+	EOFMarker       bool   // an end-of-file marker (0x1A) is expected after the data
+}
+
+// Layout returns the geometry of the file as computed from the header.
+// EOFMarker is true unless the file size is known and leaves no room for
+// the marker after the last record.
+func (r *Reader) Layout() Layout {
+	layout := Layout{
+		HeaderSize:      r.headerBytesNumber,
+		RecordSize:      r.recordBytesNumber,
+		DataStartOffset: int64(r.headerBytesNumber),
+		DataSize:        int64(r.recordsCount) * int64(r.recordBytesNumber),
+		EOFMarker:       true,
+	}
+	if r.size >= 0 {
+		layout.EOFMarker = r.size > layout.DataStartOffset+layout.DataSize
+	}
+	return layout
+}
+
+// HeaderBytes returns a copy of the file header as stored: the 32-byte
+// header (68 bytes for dBASE 7) before the field descriptors.
+// It returns nil for multi-file readers.
+func (r *Reader) HeaderBytes() []byte {
+	length, _ := r.descriptorLayout()
+	if len(r.header) < length {
+		return nil
+	}
+	return bytes.Clone(r.header[:length])
+}
+
+// FieldDescriptorBytes returns a copy of the field descriptors as stored,
+// without the 0x0D terminator, including bytes that Field does not model
+// such as Visual FoxPro field flags and autoincrement values.
+// It returns nil for multi-file readers.
+func (r *Reader) FieldDescriptorBytes() []byte {
+	start, descriptorLength := r.descriptorLayout()
+	end := start + len(r.fields)*descriptorLength
+	if len(r.header) < end {
+		return nil
+	}
+	return bytes.Clone(r.header[start:end])
+}
+
+// descriptorLayout returns the offset of the first field descriptor and the
+// size of a descriptor.
+func (r *Reader) descriptorLayout() (start, length int) {
+	if r.dBASE7Mode {
+		return int(dBASE7MetadataLength), int(dBASE7FieldLength)
+	}
+	return int(metadataLength), int(fieldLength)
+}
+
+// DeletionBitmap returns the deletion flag of every record, indexed by
+// record number, without decoding any field. Only the first byte of each
+// record is read, so this is much faster than reading full records.
+//
+// The flags are read through io.ReaderAt and the position used by
+// Next()/Read() is not affected.
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) DeletionBitmap() ([]bool, error) {
+	bitmap := make([]bool, r.recordsCount)
+	if err := r.scanDeletionFlags(func(index uint32, deleted bool) {
+		bitmap[index] = deleted
+	}); err != nil {
+		return nil, err
+	}
+	return bitmap, nil
+}
+
+// RecordHashes returns a 64-bit FNV-1a hash of the raw bytes of every
+// record, including the deletion flag, indexed by record number. Records are
+// read but not decoded, so comparing the hashes of two versions of a table
+// is a cheap way to find added, changed and removed rows.
+//
+// RecordHashes must be called before any record is read, and leaves the
+// reader positioned after the last record.
+//
+// Example:
+//
+//	before, _ := old.RecordHashes()
+//	after, _ := current.RecordHashes()
+//	for i := range min(len(before), len(after)) {
+//		if before[i] != after[i] {
+//			fmt.Println("record changed:", i)
+//		}
+//	}
+func (r *Reader) RecordHashes() ([]uint64, error) {
+	if r.segments != nil {
+		return nil, errors.New("record hashes: not supported for multi-file readers")
+	}
+	if r.currentRecord != 0 {
+		return nil, fmt.Errorf("record hashes: reader is already positioned at record %d", r.currentRecord)
+	}
+
+	hashes := make([]uint64, 0, r.recordsCount)
+	h := fnv.New64a()
+	for r.currentRecord < r.recordsCount {
+		if r.err != nil {
+			return hashes, r.err
+		}
+		if err := r.checkCancelled(); err != nil {
+			return hashes, err
+		}
+		recordBytes, err := r.readRecordBytes()
+		if err != nil {
+			return hashes, err
+		}
+		r.currentRecord++
+
+		h.Reset()
+		h.Write(recordBytes)
+		hashes = append(hashes, h.Sum64())
+	}
+
+	return hashes, nil
+}
+
+// scanDeletionFlags calls fn with the deletion flag of every record,
+// reading a single byte per record through io.ReaderAt.
+func (r *Reader) scanDeletionFlags(fn func(index uint32, deleted bool)) error {
+	if r.ra == nil {
+		return ErrNotSeekable
+	}
+
+	flag := make([]byte, 1)
+	for i := uint32(0); i < r.recordsCount; i++ {
+		offset := r.base + r.RecordOffset(i)
+		if _, err := r.ra.ReadAt(flag, offset); err != nil {
+			return fmt.Errorf("read deletion flag of record %d: %w", i, err)
+		}
+		fn(i, flag[0] == 0x2A)
+	}
+
+	return nil
+}
+
+// Rewind positions the reader before the first record, so that the next call
+// to Next() returns the first record again. Any previous iteration error is
+// cleared.
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) Rewind() error {
+	return r.seekRecord(0)
+}
+
+// seekRecord positions sequential reading at the record with the given
+// zero-based index.
+func (r *Reader) seekRecord(index uint32) error {
+	if r.ra == nil {
+		return ErrNotSeekable
+	}
+
+	offset := r.base + r.RecordOffset(index)
+	r.counter.n -= int64(r.reader.Buffered()) // drop data read ahead but never consumed
+	r.counter.r = io.NewSectionReader(r.ra, offset, math.MaxInt64-offset)
+	r.reader.Reset(r.counter)
+	r.pending = nil
+	r.currentRecord = index
+	r.err = nil
+
+	return nil
+}
+
+// Clone returns a new Reader that shares the parsed header and fields with r
+// but has its own cursor positioned at the first record. The two readers can
+// be iterated independently of each other.
+//
+// The clone reads through the original source and must not be used after the
+// original Reader is closed; closing the clone does nothing. Readers share the
+// text decoder and must not be used concurrently.
+// Returns ErrNotSeekable if the source does not implement io.ReaderAt.
+func (r *Reader) Clone() (*Reader, error) {
+	if r.ra == nil {
+		return nil, ErrNotSeekable
+	}
+
+	clone := *r
+	dataStart := r.base + int64(r.headerBytesNumber)
+	clone.counter = &countingReader{r: io.NewSectionReader(r.ra, dataStart, math.MaxInt64-dataStart)}
+	clone.reader = bufio.NewReader(clone.counter)
+	clone.currentRecord = 0
+	clone.err = nil
+	clone.warnings = slices.Clone(r.warnings)
+	clone.wa = nil
+	clone.file = nil
+	clone.memoCloser = nil
+
+	return &clone, nil
+}
+
+// parseRecord decodes a record from its raw bytes.
+// On a decode error the fields decoded so far are returned along with a *FieldError.
+func (r *Reader) parseRecord(recordBytes []byte) (*Record, error) {
+	record := &Record{
+		Deleted: len(recordBytes) > 0 && recordBytes[0] == 0x2A, // '*' marks deleted records
+		Data:    make(map[string]string, len(r.fields)),
+		fields:  r.AliasedFields(),
+	}
+
+	// parse individual fields
+	offset := 1 // skip deletion flag
+	for _, field := range r.fields {
+		fieldData, err := fieldSlice(recordBytes, offset, field)
+		if err != nil {
+			return record, &FieldError{Field: field.Name, Err: err}
+		}
+		offset += int(field.Length)
+
+		// decode field value
+		value, err := r.decodeFieldValue(field, fieldData)
+		if err != nil {
+			return record, &FieldError{Field: field.Name, Err: err}
+		}
+		if value == "" {
+			value = r.nullString
+		}
+
+		record.Data[r.dataKey(field)] = value
+	}
+
+	return record, nil
+}
+
+// fieldSlice returns the bytes of field, which starts at offset in
+// recordBytes. It returns an error instead of panicking if inconsistent
+// field lengths and record size place the field past the end of the record.
+func fieldSlice(recordBytes []byte, offset int, field Field) ([]byte, error) {
+	end := offset + int(field.Length)
+	if end > len(recordBytes) {
+		return nil, fmt.Errorf("field at offset %d with length %d exceeds record size %d", offset, field.Length, len(recordBytes))
+	}
+	return recordBytes[offset:end], nil
+}
+
+// parseRaw stores the untrimmed value of every field in record.
+// Fields stored in binary form have no padding and keep their decoded value.
+func (r *Reader) parseRaw(record *Record, recordBytes []byte) {
+	record.raw = make(map[string]string, len(r.fields))
+
+	offset := 1 // skip deletion flag
+	for _, field := range r.fields {
+		fieldData, err := fieldSlice(recordBytes, offset, field)
+		if err != nil {
+			break // reported by parseRecord
+		}
+		offset += int(field.Length)
+
+		if isBinaryField(field, r.clipperNumeric) {
+			record.raw[r.dataKey(field)] = record.Data[r.dataKey(field)]
+			continue
+		}
+		record.raw[r.dataKey(field)] = r.decodeText(field, fieldData)
+	}
+}
+
+// isBinaryField reports whether a field stores its value in binary form
+// rather than as padded text.
+func isBinaryField(field Field, clipperNumeric bool) bool {
+	switch field.Type {
+	case 'I', 'M', 'G':
+		return field.Length == 4
+	case 'N':
+		return clipperNumeric && (field.Length == 4 || field.Length == 8)
+	}
+	return false
+}
+
+// ReadAll reads all records from the DBF file into memory.
+// This is convenient for small files but may consume significant memory for large files.
+// For large files, consider using Next()/Read() for streaming access.
+//
+// Example:
+//
+//	records, err := reader.ReadAll()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, record := range records {
+//		fmt.Println(record.Data["NAME"])
+//	}
+func (r *Reader) ReadAll() ([]*Record, error) {
+	records := make([]*Record, 0, r.recordsCount)
+
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+
+	if err := r.Err(); err != nil {
+		return records, err
+	}
+
+	return records, nil
+}
+
+// contextCheckDue reports whether the context is checked before the record
+// with the given count, at the interval set by WithContextCheckInterval.
+func (r *Reader) contextCheckDue(n uint32) bool {
+	return n%max(r.ctxInterval, 1) == 0
+}
+
+// ReadAllContext is like ReadAll but stops when ctx is cancelled, returning
+// the records read so far together with the wrapped context error. The
+// context only applies to this call; it is checked at the interval set by
+// WithContextCheckInterval (every record by default).
+func (r *Reader) ReadAllContext(ctx context.Context) ([]*Record, error) {
+	records := make([]*Record, 0, r.recordsCount)
+
+	for n := uint32(0); ; n++ {
+		if r.contextCheckDue(n) {
+			if err := ctx.Err(); err != nil {
+				return records, fmt.Errorf("read all: %w", err)
+			}
+		}
+		if !r.Next() {
+			break
+		}
+
+		record, err := r.Read()
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+
+	if err := r.Err(); err != nil {
+		return records, err
+	}
+
+	return records, nil
+}
+
+// ReadN reads up to n records from the current position, or fewer if the
+// file ends first, and leaves the reader positioned to continue with the
+// next record. With WithSkipDeleted, only active records count toward n.
+//
+// Example:
+//
+//	preview, err := reader.ReadN(10)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *Reader) ReadN(n int) ([]*Record, error) {
+	if n <= 0 {
+		return []*Record{}, nil
+	}
+	records := make([]*Record, 0, min(n, int(r.recordsCount-r.currentRecord)))
+
+	for len(records) < n && r.Next() {
 		record, err := r.Read()
 		if err != nil {
 			return records, err
@@ -532,6 +2480,47 @@ func (r *Reader) ReadAll() ([]*Record, error) {
 	return records, nil
 }
 
+// PipeRecords calls fn for each remaining record in order. Iteration stops
+// when fn returns an error, which is returned as is, or when ctx is
+// cancelled, in which case the wrapped context error is returned. If fn
+// returns ErrStopIteration, iteration stops and PipeRecords returns nil.
+//
+// Example:
+//
+//	err := reader.PipeRecords(ctx, func(rec *dbf.Record) error {
+//		if rec.Data["ID"] == target {
+//			found = rec
+//			return dbf.ErrStopIteration
+//		}
+//		return nil
+//	})
+func (r *Reader) PipeRecords(ctx context.Context, fn func(*Record) error) error {
+	for n := uint32(0); ; n++ {
+		if r.contextCheckDue(n) {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("pipe records: %w", err)
+			}
+		}
+		if !r.Next() {
+			break
+		}
+
+		record, err := r.Read()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return r.Err()
+}
+
 // Err returns any error that occurred during iteration.
 // It should be called after Next() returns false to check for errors.
 // Returns nil if iteration completed successfully (io.EOF is not returned).
@@ -548,13 +2537,12 @@ func (r *Reader) decodeFieldValue(field Field, data []byte) (string, error) {
 
 	switch field.Type {
 	case 'C': // character field
-		decoded, err := r.decoder.Bytes(trimmed)
-		if err != nil {
-			return string(trimmed), nil // fallback to raw bytes
-		}
-		return string(decoded), nil
+		return r.decodeText(field, r.trimText(data)), nil
 
 	case 'N', 'F': // numeric and Float fields
+		if r.clipperNumeric && field.Type == 'N' && (len(data) == 4 || len(data) == 8) {
+			return decodeBinaryFloat(field, data), nil
+		}
 		return string(trimmed), nil
 
 	case 'D': // date field (format: YYYYMMDD)
@@ -571,21 +2559,148 @@ func (r *Reader) decodeFieldValue(field Field, data []byte) (string, error) {
 		}
 		return "", nil
 
-	case 'M': // memo field (reference to external memo file)
+	case 'M', 'G': // memo and general (OLE) fields (block reference to external memo file)
+		if len(data) == 4 {
+			// Visual FoxPro stores the block number as a 4-byte little-endian integer
+			if block := binary.LittleEndian.Uint32(data); block != 0 {
+				return strconv.FormatUint(uint64(block), 10), nil
+			}
+			return "", nil
+		}
 		return string(trimmed), nil
 
-	default: // unknown field type - try to decode as character
-		decoded, err := r.decoder.Bytes(trimmed)
-		if err != nil {
+	case 'I': // integer field (4-byte little-endian signed binary)
+		if len(data) != 4 {
 			return string(trimmed), nil
 		}
-		return string(decoded), nil
+		return strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(data))), 10), nil
+
+	default: // unknown field type - try the custom decoder, then decode as character
+		if r.customDecoder != nil {
+			if value, err := r.customDecoder(field.Type, data); err == nil {
+				return value, nil
+			}
+		}
+		return r.decodeText(field, r.trimText(data)), nil
+	}
+}
+
+// trimText removes padding from character data according to the trim mode.
+func (r *Reader) trimText(data []byte) []byte {
+	switch r.trimMode {
+	case TrimTrailing:
+		return bytes.TrimRightFunc(data, unicode.IsSpace)
+	case TrimLeading:
+		return bytes.TrimLeftFunc(data, unicode.IsSpace)
+	case TrimNone:
+		return data
+	default:
+		return bytes.TrimSpace(data)
+	}
+}
+
+// resolveFieldDecoders checks the fields given to WithFieldEncoding and keys
+// their decoders by field name.
+func (r *Reader) resolveFieldDecoders() error {
+	resolved := make(map[string]*encoding.Decoder, len(r.fieldDecoders))
+	for name, decoder := range r.fieldDecoders {
+		field, ok := r.field(name)
+		if !ok {
+			return fmt.Errorf("field %s: %w", name, ErrUnknownField)
+		}
+		if field.Type != 'C' && field.Type != 'M' {
+			return fmt.Errorf("field %s: not a character or memo field", name)
+		}
+		resolved[field.Name] = decoder
+	}
+	r.fieldDecoders = resolved
+	return nil
+}
+
+// fieldDecoder returns the decoder for the values of field.
+func (r *Reader) fieldDecoder(field Field) *encoding.Decoder {
+	if decoder, ok := r.fieldDecoders[field.Name]; ok {
+		return decoder
+	}
+	return r.decoder
+}
+
+// decodeText decodes character data, falling back to the raw bytes if the
+// data cannot be decoded.
+func (r *Reader) decodeText(field Field, data []byte) string {
+	decoded, err := r.fieldDecoder(field).Bytes(data)
+	if err != nil {
+		if r.ignoreDecodeErrors {
+			r.warn("decode field %s: %v; using raw bytes", field.Name, err)
+		}
+		return string(data)
 	}
+	return string(decoded)
 }
 
+// ParseDate parses the value of a date (D) field as returned by Read().
+// A blank or null value returns the zero time and no error.
+// Two-digit years are expanded if the reader was created with WithDateCentury.
+func (r *Reader) ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == r.nullString {
+		return time.Time{}, nil
+	}
+
+	if r.datePivot >= 0 && len(value) == 6 {
+		value = "00" + value
+	}
+
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse date %q: %w", value, err)
+	}
+
+	if r.datePivot >= 0 && t.Year() < 100 {
+		year := 1900 + t.Year()
+		if t.Year() <= r.datePivot {
+			year = 2000 + t.Year()
+		}
+		t = time.Date(year, t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return t, nil
+}
+
+// decodeBinaryFloat formats a little-endian float32 (4 bytes) or float64
+// (8 bytes) numeric value with the field's decimal count.
+func decodeBinaryFloat(field Field, data []byte) string {
+	precision := -1
+	if field.DecimalCount > 0 {
+		precision = int(field.DecimalCount)
+	}
+
+	if len(data) == 4 {
+		v := math.Float32frombits(binary.LittleEndian.Uint32(data))
+		return strconv.FormatFloat(float64(v), 'f', precision, 32)
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+// Close closes the underlying file if the Reader was created by NewFromFile
+// or OpenMulti, and the memo file if the reader opened one. It does nothing
+// for readers created from an io.Reader.
 func (r *Reader) Close() error {
+	if r.segments != nil {
+		var errs []error
+		for _, segment := range r.segments {
+			errs = append(errs, segment.Close())
+		}
+		return errors.Join(errs...)
+	}
+
+	var errs []error
+	if r.memoCloser != nil {
+		errs = append(errs, r.memoCloser.Close())
+	}
 	if r.file != nil {
-		return r.file.Close()
+		errs = append(errs, r.file.Close())
 	}
-	return nil
-}
\ No newline at end of file
+	return errors.Join(errs...)
+}
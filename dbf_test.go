@@ -2,12 +2,25 @@ package dbf
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
 )
 
 // createMinimalDBF creates a minimal valid DBF file for testing
@@ -219,6 +232,34 @@ func TestLastUpdate(t *testing.T) {
 	}
 }
 
+func TestLastUpdateYearRange(t *testing.T) {
+	tests := []struct {
+		yearByte byte
+		expected int
+	}{
+		{0, 1900},
+		{1, 1901},
+		{99, 1999},
+		{100, 2000},
+		{124, 2024},
+		{199, 2099},
+		{255, 2155},
+	}
+
+	for _, tt := range tests {
+		data := createMinimalDBF()
+		data[1] = tt.yearByte
+
+		dbf, err := New(bytes.NewReader(data), WithCP866())
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if year := dbf.LastUpdate().Year(); year != tt.expected {
+			t.Errorf("Year byte %d: expected %d, got %d", tt.yearByte, tt.expected, year)
+		}
+	}
+}
+
 func TestFields(t *testing.T) {
 	data := createDBFWithMultipleFields()
 	reader := bytes.NewReader(data)
@@ -272,6 +313,7 @@ func TestFieldTypeString(t *testing.T) {
 		{'L', "Logical"},
 		{'M', "Memo"},
 		{'F', "Float"},
+		{'G', "General"},
 		{'X', "Unknown (X)"},
 	}
 
@@ -283,6 +325,44 @@ func TestFieldTypeString(t *testing.T) {
 	}
 }
 
+func TestFieldDigits(t *testing.T) {
+	tests := []struct {
+		field            Field
+		integer, decimal int
+	}{
+		{NewNumericField("QTY", 5, 0), 5, 0},
+		{NewNumericField("PRICE", 10, 2), 7, 2},
+		{NewNumericField("RATE", 3, 2), 0, 2},
+	}
+
+	for _, tt := range tests {
+		if got := tt.field.MaxIntegerDigits(); got != tt.integer {
+			t.Errorf("%s: expected %d integer digits, got %d", tt.field.Name, tt.integer, got)
+		}
+		if got := tt.field.MaxDecimalPrecision(); got != tt.decimal {
+			t.Errorf("%s: expected %d decimal digits, got %d", tt.field.Name, tt.decimal, got)
+		}
+	}
+}
+
+func TestFieldIsNullFlags(t *testing.T) {
+	tests := []struct {
+		field    Field
+		expected bool
+	}{
+		{Field{Name: "_NullFlags", Type: '0', Length: 1}, true},
+		{Field{Name: "_NULLFLAGS", Type: '0', Length: 1}, true}, // WithUpperCaseNames
+		{Field{Name: "_NullFlags", Type: 'C', Length: 1}, false},
+		{Field{Name: "FLAGS", Type: '0', Length: 1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.field.IsNullFlags(); got != tt.expected {
+			t.Errorf("%s (%c): expected %v, got %v", tt.field.Name, tt.field.Type, tt.expected, got)
+		}
+	}
+}
+
 func TestReadAll(t *testing.T) {
 	data := createMinimalDBF()
 	reader := bytes.NewReader(data)
@@ -621,46 +701,2722 @@ func TestZeroRecords(t *testing.T) {
 	}
 }
 
-// Benchmark tests
-func BenchmarkNew(b *testing.B) {
+func TestGetDecoderByLDIDNoDriver(t *testing.T) {
+	decoder := getDecoderByLDID(0x00)
+	if decoder == nil {
+		t.Fatal("getDecoderByLDID(0x00) returned nil")
+	}
+
+	decoded, err := decoder.Bytes([]byte{'A', 0xE9})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(decoded) != "Aé" {
+		t.Errorf("Expected 'Aé', got '%s'", decoded)
+	}
+}
+
+func TestEncodingAutodetectionNoDriver(t *testing.T) {
+	// createMinimalDBF has LDID 0x00
+	dbf, err := New(bytes.NewReader(createMinimalDBF()))
+	if err != nil {
+		t.Fatalf("New() with LDID 0x00 failed: %v", err)
+	}
+
+	if len(dbf.Warnings()) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", dbf.Warnings())
+	}
+	if !strings.Contains(dbf.Warnings()[0], "LDID 0x00") {
+		t.Errorf("Warning should mention LDID 0x00, got: %s", dbf.Warnings()[0])
+	}
+
+	// explicit encoding should not produce a warning
+	dbf, err = New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if len(dbf.Warnings()) != 0 {
+		t.Errorf("Expected no warnings, got %v", dbf.Warnings())
+	}
+}
+
+func TestWithUpperCaseNames(t *testing.T) {
 	data := createMinimalDBF()
+	copy(data[32:36], "name") // lower-case field name
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		reader := bytes.NewReader(data)
-		_, err := New(reader, WithCP866())
-		if err != nil {
-			b.Fatal(err)
-		}
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.Fields()[0].Name != "name" {
+		t.Errorf("Expected field name 'name' without option, got '%s'", dbf.Fields()[0].Name)
+	}
+
+	dbf, err = New(bytes.NewReader(data), WithCP866(), WithUpperCaseNames())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	field := dbf.Fields()[0]
+	if field.Name != "NAME" {
+		t.Errorf("Expected field name 'NAME', got '%s'", field.Name)
+	}
+	if field.RawName != "name" {
+		t.Errorf("Expected raw name 'name', got '%s'", field.RawName)
+	}
+
+	record, err := dbf.ReadAt(0)
+	if err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if record.Data["NAME"] != "John Doe" {
+		t.Errorf("Expected Data[\"NAME\"] = 'John Doe', got '%s'", record.Data["NAME"])
 	}
 }
 
-func BenchmarkReadAll(b *testing.B) {
+func TestNewFromReaderAndBytes(t *testing.T) {
 	data := createMinimalDBF()
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		reader := bytes.NewReader(data)
-		dbf, _ := New(reader, WithCP866())
-		_, err := dbf.ReadAll()
+	fromReader, err := NewFromReader(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromReader() failed: %v", err)
+	}
+
+	fromBytes, err := NewFromBytes(data, WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+
+	if fromReader.RecordsCount() != 2 || fromBytes.RecordsCount() != 2 {
+		t.Errorf("Expected 2 records, got %d and %d", fromReader.RecordsCount(), fromBytes.RecordsCount())
+	}
+
+	// bytes-backed readers support random access
+	if _, err := fromBytes.ReadAt(1); err != nil {
+		t.Errorf("ReadAt() on NewFromBytes reader failed: %v", err)
+	}
+}
+
+func TestUnknownFieldTypes(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if unknown := dbf.UnknownFieldTypes(); len(unknown) != 0 {
+		t.Errorf("Expected no unknown field types, got %q", unknown)
+	}
+
+	dbf.fields = []Field{{Name: "A", Type: 'C'}, {Name: "B", Type: 'P'}, {Name: "C", Type: 'X'}, {Name: "D", Type: 'P'}}
+	if unknown := dbf.UnknownFieldTypes(); string(unknown) != "PX" {
+		t.Errorf("Expected unknown field types \"PX\", got %q", unknown)
+	}
+}
+
+func TestClone(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// advance the original by one record
+	if !dbf.Next() {
+		t.Fatal("Next() returned false")
+	}
+	if _, err := dbf.Read(); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	clone, err := dbf.Clone()
+	if err != nil {
+		t.Fatalf("Clone() failed: %v", err)
+	}
+
+	records, err := clone.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() on clone failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Clone should start at the first record, got %d records", len(records))
+	}
+
+	// the original continues where it left off
+	records, err = dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() on original failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Original should continue at the second record, got %d records", len(records))
+	}
+}
+
+func TestCloneNotSeekable(t *testing.T) {
+	dbf, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := dbf.Clone(); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestHeaderAndRecordSize(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if dbf.HeaderSize() != 32+32*3+1 {
+		t.Errorf("Expected header size %d, got %d", 32+32*3+1, dbf.HeaderSize())
+	}
+	if dbf.RecordSize() != 1+10+3+8 {
+		t.Errorf("Expected record size %d, got %d", 1+10+3+8, dbf.RecordSize())
+	}
+	if offset := dbf.RecordOffset(2); offset != 129+2*22 {
+		t.Errorf("Expected record offset %d, got %d", 129+2*22, offset)
+	}
+}
+
+func TestLDID(t *testing.T) {
+	// LDID is reported even when the encoding is set explicitly
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP1251())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.LDID() != 0x26 {
+		t.Errorf("Expected LDID 0x26, got 0x%02X", dbf.LDID())
+	}
+
+	dbf, err = New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.LDID() != 0x00 {
+		t.Errorf("Expected LDID 0x00, got 0x%02X", dbf.LDID())
+	}
+}
+
+func TestGeneralFieldDecoding(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	value, err := dbf.decodeFieldValue(Field{Name: "OLE", Type: 'G', Length: 10}, []byte("        42"))
+	if err != nil {
+		t.Fatalf("decodeFieldValue() failed: %v", err)
+	}
+	if value != "42" {
+		t.Errorf("Expected block reference '42', got '%s'", value)
+	}
+}
+
+// createDBFWithLogicalFields creates a DBF with a single logical field
+// ACTIVE and one record per value
+func createDBFWithLogicalFields(values []byte) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(0x03)
+	buf.WriteByte(124)
+	buf.WriteByte(1)
+	buf.WriteByte(1)
+
+	binary.Write(buf, binary.LittleEndian, uint32(len(values)))
+	binary.Write(buf, binary.LittleEndian, uint16(32+32+1))
+	binary.Write(buf, binary.LittleEndian, uint16(1+1))
+	buf.Write(make([]byte, 20))
+
+	active := append([]byte("ACTIVE"), make([]byte, 5)...)
+	buf.Write(active)
+	buf.WriteByte('L')
+	buf.Write(make([]byte, 4))
+	buf.WriteByte(1)
+	buf.WriteByte(0)
+	buf.Write(make([]byte, 14))
+
+	buf.WriteByte(0x0D)
+
+	for _, v := range values {
+		buf.WriteByte(0x20)
+		buf.WriteByte(v)
+	}
+
+	return buf.Bytes()
+}
+
+func TestLogicalFieldDecoding(t *testing.T) {
+	tests := []struct {
+		value    byte
+		expected string
+	}{
+		{'T', "true"},
+		{'t', "true"},
+		{'Y', "true"},
+		{'y', "true"},
+		{'F', "false"},
+		{'f', "false"},
+		{'N', "false"},
+		{'n', "false"},
+		{'?', ""},  // not initialized
+		{' ', ""},  // space
+		{0x00, ""}, // blank
+		{'X', ""},  // invalid
+		{'1', ""},  // invalid
+	}
+
+	values := make([]byte, len(tests))
+	for i, tt := range tests {
+		values[i] = tt.value
+	}
+
+	dbf, err := New(bytes.NewReader(createDBFWithLogicalFields(values)), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	field := dbf.Fields()[0]
+	for _, tt := range tests {
+		value, err := dbf.decodeFieldValue(field, []byte{tt.value})
 		if err != nil {
-			b.Fatal(err)
+			t.Errorf("decodeFieldValue(%q) failed: %v", tt.value, err)
+		}
+		if value != tt.expected {
+			t.Errorf("decodeFieldValue(%q): expected '%s', got '%s'", tt.value, tt.expected, value)
+		}
+	}
+
+	// the same values through the reader
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != len(tests) {
+		t.Fatalf("Expected %d records, got %d", len(tests), len(records))
+	}
+	for i, tt := range tests {
+		if got := records[i].Data["ACTIVE"]; got != tt.expected {
+			t.Errorf("Record %d (%q): expected '%s', got '%s'", i, tt.value, tt.expected, got)
 		}
 	}
 }
 
-func BenchmarkNextRead(b *testing.B) {
+// createDBase7DBF creates a dBASE 7 file with 48-byte field descriptors
+func createDBase7DBF(fileType byte) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(fileType)
+	buf.WriteByte(124)
+	buf.WriteByte(3)
+	buf.WriteByte(1)
+
+	binary.Write(buf, binary.LittleEndian, uint32(1))         // 1 record
+	binary.Write(buf, binary.LittleEndian, uint16(68+48*2+1)) // 2 fields
+	binary.Write(buf, binary.LittleEndian, uint16(1+20+5))    // record size
+	buf.Write(make([]byte, 20))                               // reserved
+	buf.Write(append([]byte("DB7LANG"), make([]byte, 25)...)) // language driver name
+	buf.Write(make([]byte, 4))                                // reserved
+
+	writeField := func(name string, fieldType byte, length, decimals byte) {
+		buf.Write(append([]byte(name), make([]byte, 32-len(name))...))
+		buf.WriteByte(fieldType)
+		buf.WriteByte(length)
+		buf.WriteByte(decimals)
+		buf.Write(make([]byte, 13))
+	}
+	writeField("CUSTOMER_FULL_NAME_LONG", 'C', 20, 0)
+	writeField("AMOUNT", 'N', 5, 2)
+
+	buf.WriteByte(0x0D)
+
+	buf.WriteByte(0x20)
+	buf.WriteString("Alice Cooper        ")
+	buf.WriteString(" 1.50")
+
+	return buf.Bytes()
+}
+
+func TestDBase7ExtendedFieldNames(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBase7DBF(0x04)), WithCP1252())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if dbf.FileType().String() != "dBASE 7 without memo" {
+		t.Errorf("Unexpected file type: %s", dbf.FileType())
+	}
+
+	fields := dbf.Fields()
+	if len(fields) != 2 {
+		t.Fatalf("Expected 2 fields, got %d", len(fields))
+	}
+	if fields[0].Name != "CUSTOMER_FULL_NAME_LONG" || fields[0].Length != 20 {
+		t.Errorf("Unexpected first field: %+v", fields[0])
+	}
+	if fields[1].Name != "AMOUNT" || fields[1].Type != 'N' || fields[1].DecimalCount != 2 {
+		t.Errorf("Unexpected second field: %+v", fields[1])
+	}
+
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["CUSTOMER_FULL_NAME_LONG"] != "Alice Cooper" || records[0].Data["AMOUNT"] != "1.50" {
+		t.Errorf("Unexpected record data: %v", records[0].Data)
+	}
+}
+
+func TestWithDBase7Mode(t *testing.T) {
+	// a non-standard type byte is only readable with the forced mode
+	data := createDBase7DBF(0x03)
+
+	if _, err := New(bytes.NewReader(data), WithCP1252()); err == nil {
+		t.Error("Expected error when reading dBASE 7 layout without dBASE 7 mode")
+	}
+
+	dbf, err := New(bytes.NewReader(data), WithCP1252(), WithDBase7Mode())
+	if err != nil {
+		t.Fatalf("New() with WithDBase7Mode() failed: %v", err)
+	}
+	if dbf.Fields()[0].Name != "CUSTOMER_FULL_NAME_LONG" {
+		t.Errorf("Expected long field name, got '%s'", dbf.Fields()[0].Name)
+	}
+}
+
+func TestBytesRead(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if dbf.BytesRead() != 65 {
+		t.Errorf("Expected 65 bytes read after header, got %d", dbf.BytesRead())
+	}
+
+	dbf.Next()
+	if _, err := dbf.Read(); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if dbf.BytesRead() != 65+11 {
+		t.Errorf("Expected %d bytes read after first record, got %d", 65+11, dbf.BytesRead())
+	}
+
+	// random access is not counted
+	if _, err := dbf.ReadAt(1); err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if dbf.BytesRead() != 65+11 {
+		t.Errorf("ReadAt() should not change BytesRead(), got %d", dbf.BytesRead())
+	}
+}
+
+func TestWithValidateFieldLengths(t *testing.T) {
+	if _, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithValidateFieldLengths()); err != nil {
+		t.Fatalf("New() failed on consistent file: %v", err)
+	}
+
 	data := createMinimalDBF()
+	binary.LittleEndian.PutUint16(data[10:12], 12) // declare one byte too many
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		reader := bytes.NewReader(data)
-		dbf, _ := New(reader, WithCP866())
-		for dbf.Next() {
-			_, err := dbf.Read()
-			if err != nil {
+	// without the option the declared size is used silently
+	if _, err := New(bytes.NewReader(data), WithCP866()); err != nil {
+		t.Fatalf("New() failed without validation: %v", err)
+	}
+
+	_, err := New(bytes.NewReader(data), WithCP866(), WithValidateFieldLengths())
+	var mismatch *FieldLengthMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected FieldLengthMismatchError, got %v", err)
+	}
+	if mismatch.Declared != 12 || mismatch.Computed != 11 {
+		t.Errorf("Expected declared 12 and computed 11, got %d and %d", mismatch.Declared, mismatch.Computed)
+	}
+}
+
+func TestWithAcceptRecordCountMismatch(t *testing.T) {
+	data := createMinimalDBF()
+	binary.LittleEndian.PutUint32(data[4:8], 5) // declare more records than present
+
+	dbf, err := New(bytes.NewReader(data), WithCP866(), WithAcceptRecordCountMismatch())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.RecordsCount() != 2 {
+		t.Errorf("Expected 2 records, got %d", dbf.RecordsCount())
+	}
+	if len(dbf.Warnings()) != 1 || !strings.Contains(dbf.Warnings()[0], "declares 5 records") {
+		t.Errorf("Expected a record count warning, got %v", dbf.Warnings())
+	}
+
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records from ReadAll(), got %d", len(records))
+	}
+
+	// the EOF marker is not counted as a record
+	withEOF := append(createMinimalDBF(), 0x1A)
+	dbf, err = New(bytes.NewReader(withEOF), WithCP866(), WithAcceptRecordCountMismatch())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.RecordsCount() != 2 || len(dbf.Warnings()) != 0 {
+		t.Errorf("Expected 2 records without warnings, got %d and %v", dbf.RecordsCount(), dbf.Warnings())
+	}
+
+	// ignored for sources that are not seekable
+	dbf, err = New(streamOnly{bytes.NewReader(data)}, WithCP866(), WithAcceptRecordCountMismatch())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.RecordsCount() != 5 {
+		t.Errorf("Expected declared count 5 for non-seekable source, got %d", dbf.RecordsCount())
+	}
+}
+
+func TestMarkDeletedAndUndelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.dbf")
+	if err := os.WriteFile(path, createMinimalDBF(), 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("OpenFile() failed: %v", err)
+	}
+	defer file.Close()
+
+	dbf, err := New(file, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if err := dbf.MarkDeleted(0); err != nil {
+		t.Fatalf("MarkDeleted(0) failed: %v", err)
+	}
+	if err := dbf.Undelete(1); err != nil {
+		t.Fatalf("Undelete(1) failed: %v", err)
+	}
+	if err := dbf.MarkDeleted(2); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if data[65] != '*' || data[76] != ' ' {
+		t.Errorf("Unexpected deletion flags: 0x%02X, 0x%02X", data[65], data[76])
+	}
+}
+
+func TestMarkDeletedReadOnly(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := dbf.MarkDeleted(0); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+
+	// files opened by NewFromFile are read-only
+	dbf, err = NewFromFile(writeTestFile(t, "data.dbf", createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer dbf.Close()
+
+	if err := dbf.MarkDeleted(0); err == nil {
+		t.Error("Expected error when writing to a read-only file, got nil")
+	}
+}
+
+func TestFieldOffsetInFile(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// header 129 bytes, record: flag(1) + NAME(10) + AGE(3) + BIRTHDATE(8)
+	offset, ok := dbf.FieldOffsetInFile(0, "BIRTHDATE")
+	if !ok || offset != 129+1+10+3 {
+		t.Errorf("Expected offset %d, got %d (ok=%v)", 129+1+10+3, offset, ok)
+	}
+
+	if _, ok := dbf.FieldOffsetInFile(0, "MISSING"); ok {
+		t.Error("Expected false for unknown field")
+	}
+	if _, ok := dbf.FieldOffsetInFile(1, "NAME"); ok {
+		t.Error("Expected false for out of range record")
+	}
+}
+
+func TestWithRecordCountFromFile(t *testing.T) {
+	data := createMinimalDBF()
+	binary.LittleEndian.PutUint32(data[4:8], 0) // header says no records
+
+	dbf, err := New(bytes.NewReader(data), WithCP866(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if dbf.RecordsCount() != 2 {
+		t.Errorf("Expected 2 records, got %d", dbf.RecordsCount())
+	}
+
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records from ReadAll(), got %d", len(records))
+	}
+
+	_, err = New(streamOnly{bytes.NewReader(data)}, WithCP866(), WithRecordCountFromFile())
+	if !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestMultiByteEncodings(t *testing.T) {
+	tests := []struct {
+		ldid    byte
+		encoded []byte
+		decoded string
+	}{
+		{0x7B, []byte{0x93, 0xFA, 0x96, 0x7B}, "日本"}, // Shift-JIS
+		{0x7A, []byte{0xD6, 0xD0, 0xCE, 0xC4}, "中文"}, // GBK
+		{0x78, []byte{0xA4, 0xA4, 0xA4, 0xE5}, "中文"}, // Big5
+	}
+
+	for _, tt := range tests {
+		data := createMinimalDBF()
+		data[29] = tt.ldid
+		copy(data[66:76], append(tt.encoded, bytes.Repeat([]byte{' '}, 10-len(tt.encoded))...))
+
+		dbf, err := New(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("LDID 0x%02X: New() failed: %v", tt.ldid, err)
+		}
+		record, err := dbf.ReadAt(0)
+		if err != nil {
+			t.Fatalf("LDID 0x%02X: ReadAt() failed: %v", tt.ldid, err)
+		}
+		if record.Data["NAME"] != tt.decoded {
+			t.Errorf("LDID 0x%02X: expected '%s', got '%s'", tt.ldid, tt.decoded, record.Data["NAME"])
+		}
+	}
+}
+
+func TestWithEncodingTransformer(t *testing.T) {
+	data := createMinimalDBF()
+	copy(data[66:76], []byte{0x93, 0xFA, 0x96, 0x7B, ' ', ' ', ' ', ' ', ' ', ' '})
+
+	dbf, err := New(bytes.NewReader(data), WithEncodingTransformer(japanese.ShiftJIS))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	record, err := dbf.ReadAt(0)
+	if err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if record.Data["NAME"] != "日本" {
+		t.Errorf("Expected '日本', got '%s'", record.Data["NAME"])
+	}
+}
+
+func TestRecordEqual(t *testing.T) {
+	a := &Record{Data: map[string]string{"NAME": "John", "AGE": "25"}}
+	b := &Record{Data: map[string]string{"NAME": "John", "AGE": "25"}}
+
+	if !a.Equal(b) {
+		t.Error("Records with the same data should be equal")
+	}
+
+	b.Deleted = true
+	if a.Equal(b) {
+		t.Error("Records with different deletion flags should not be equal")
+	}
+
+	c := &Record{Data: map[string]string{"NAME": "John", "AGE": "26"}}
+	if a.Equal(c) {
+		t.Error("Records with different values should not be equal")
+	}
+
+	if a.Equal(nil) {
+		t.Error("Record should not equal nil")
+	}
+}
+
+func TestDiffRecords(t *testing.T) {
+	a := &Record{Data: map[string]string{"NAME": "John", "AGE": "25", "CITY": "Oslo"}}
+	b := &Record{Data: map[string]string{"NAME": "John", "AGE": "26", "EMAIL": "j@x"}}
+
+	diff := DiffRecords(a, b)
+	if len(diff) != 3 {
+		t.Fatalf("Expected 3 changed fields, got %v", diff)
+	}
+	if diff["AGE"] != [2]string{"25", "26"} {
+		t.Errorf("Unexpected AGE diff: %v", diff["AGE"])
+	}
+	if diff["CITY"] != [2]string{"Oslo", ""} {
+		t.Errorf("Unexpected CITY diff: %v", diff["CITY"])
+	}
+	if diff["EMAIL"] != [2]string{"", "j@x"} {
+		t.Errorf("Unexpected EMAIL diff: %v", diff["EMAIL"])
+	}
+}
+
+func TestDiffRecordsNil(t *testing.T) {
+	rec := &Record{Data: map[string]string{"NAME": "John", "AGE": "25"}}
+
+	diff := DiffRecords(nil, rec)
+	if len(diff) != 2 || diff["NAME"] != [2]string{"", "John"} || diff["AGE"] != [2]string{"", "25"} {
+		t.Errorf("Unexpected diff against nil: %v", diff)
+	}
+
+	diff = DiffRecords(rec, nil)
+	if len(diff) != 2 || diff["NAME"] != [2]string{"John", ""} || diff["AGE"] != [2]string{"25", ""} {
+		t.Errorf("Unexpected diff with nil: %v", diff)
+	}
+
+	if diff := DiffRecords(nil, nil); len(diff) != 0 {
+		t.Errorf("Expected no differences between nil records, got %v", diff)
+	}
+}
+
+func TestStat(t *testing.T) {
+	data := createMinimalDBF()
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	stat := dbf.Stat()
+	if stat.RecordCount != 2 || stat.ActiveCount != 1 || stat.DeletedCount != 1 {
+		t.Errorf("Unexpected counts: %+v", stat)
+	}
+	if stat.FileSize != int64(len(data)) {
+		t.Errorf("Expected file size %d, got %d", len(data), stat.FileSize)
+	}
+	if stat.FieldCount != 1 || stat.RecordSize != 11 {
+		t.Errorf("Unexpected layout: %+v", stat)
+	}
+	if stat.HasMemoFile {
+		t.Error("Expected no memo file")
+	}
+
+	// Stat does not consume records
+	records, err := dbf.ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Errorf("Expected 2 records after Stat(), got %d (%v)", len(records), err)
+	}
+}
+
+func TestStatWithAttachedMemo(t *testing.T) {
+	memoPath := filepath.Join(t.TempDir(), "notes.fpt")
+	if err := os.WriteFile(memoPath, make([]byte, 512), 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	dbf, err := NewFromReader(bytes.NewReader(createMinimalDBF()), WithCP866(), WithMemoFile(memoPath))
+	if err != nil {
+		t.Fatalf("NewFromReader() failed: %v", err)
+	}
+	defer dbf.Close()
+
+	if !dbf.Stat().HasMemoFile {
+		t.Error("Expected HasMemoFile to be true for a memo attached with WithMemoFile")
+	}
+}
+
+func TestStatWithMemoFile(t *testing.T) {
+	path := writeTestFile(t, "data.dbf", createMinimalDBF())
+	if err := os.WriteFile(strings.TrimSuffix(path, ".dbf")+".FPT", make([]byte, 512), 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	dbf, err := NewFromFile(path, WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer dbf.Close()
+
+	if !dbf.Stat().HasMemoFile {
+		t.Error("Expected HasMemoFile to be true")
+	}
+}
+
+func TestStatNotSeekable(t *testing.T) {
+	dbf, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	stat := dbf.Stat()
+	if stat.RecordCount != 2 || stat.ActiveCount != 0 || stat.DeletedCount != 0 || stat.FileSize != -1 {
+		t.Errorf("Unexpected stat for non-seekable source: %+v", stat)
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	cause := errors.New("bad value")
+	var err error = &FieldError{Field: "AGE", Err: cause}
+
+	if err.Error() != "decode field AGE: bad value" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("FieldError should unwrap to the underlying error")
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(fmt.Errorf("record 3: %w", err), &fieldErr) || fieldErr.Field != "AGE" {
+		t.Error("Expected to extract FieldError from wrapped error")
+	}
+}
+
+func TestWithPartialRecordsValidData(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866(), WithPartialRecords())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["AGE"] != "25" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+}
+
+func TestRecordRuneLen(t *testing.T) {
+	record := &Record{Data: map[string]string{"NAME": "日本語", "CODE": "ABC"}}
+
+	if n := record.RuneLen("NAME"); n != 3 {
+		t.Errorf("Expected 3 runes, got %d", n)
+	}
+	if n := record.RuneLen("CODE"); n != 3 {
+		t.Errorf("Expected 3 runes, got %d", n)
+	}
+	if n := record.RuneLen("MISSING"); n != 0 {
+		t.Errorf("Expected 0 runes for missing field, got %d", n)
+	}
+}
+
+func TestWithPermissiveFileType(t *testing.T) {
+	data := createMinimalDBF()
+	data[0] = 0x7F // unknown file type
+
+	if _, err := New(bytes.NewReader(data), WithCP866()); err == nil {
+		t.Fatal("Expected error for unknown file type without permissive mode")
+	}
+
+	dbf, err := New(bytes.NewReader(data), WithCP866(), WithPermissiveFileType())
+	if err != nil {
+		t.Fatalf("New() with WithPermissiveFileType() failed: %v", err)
+	}
+
+	if dbf.FileType().String() != "Unknown (0x7F)" {
+		t.Errorf("Expected raw file type in String(), got '%s'", dbf.FileType())
+	}
+	if len(dbf.Warnings()) != 1 || !strings.Contains(dbf.Warnings()[0], "0x7F") {
+		t.Errorf("Expected a file type warning, got %v", dbf.Warnings())
+	}
+
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+}
+
+func TestWithSkipDeleted(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Expected 'John Doe', got '%s'", records[0].Data["NAME"])
+	}
+	if reader.RecordsCount() != 2 {
+		t.Errorf("Expected RecordsCount() 2, got %d", reader.RecordsCount())
+	}
+}
+
+func TestNextRecord(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var names []string
+	for {
+		record, ok, err := reader.NextRecord()
+		if err != nil {
+			t.Fatalf("NextRecord() failed: %v", err)
+		}
+		if !ok {
+			break
+		}
+		names = append(names, record.Data["NAME"])
+	}
+
+	if len(names) != 2 || names[0] != "John Doe" || names[1] != "Jane Smith" {
+		t.Errorf("Expected [John Doe Jane Smith], got %v", names)
+	}
+
+	// further calls keep reporting a clean end
+	if _, ok, err := reader.NextRecord(); ok || err != nil {
+		t.Errorf("Expected ok=false and nil error after end, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestNextRecordTruncated(t *testing.T) {
+	data := createMinimalDBF()
+
+	// cut the second record in half
+	reader, err := New(bytes.NewReader(data[:80]), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, ok, err := reader.NextRecord(); !ok || err != nil {
+		t.Fatalf("Expected first record, got ok=%v err=%v", ok, err)
+	}
+
+	record, ok, err := reader.NextRecord()
+	if ok || record != nil {
+		t.Error("Expected ok=false and nil record for truncated record")
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	record, err := reader.Recover(1)
+	if err != nil {
+		t.Fatalf("Recover() failed: %v", err)
+	}
+	if !record.Deleted {
+		t.Error("Expected recovered record to be deleted")
+	}
+	if record.Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got '%s'", record.Data["NAME"])
+	}
+
+	if _, err := reader.Recover(5); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestRecoverAll(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.RecoverAll()
+	if err != nil {
+		t.Fatalf("RecoverAll() failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 deleted record, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got '%s'", records[0].Data["NAME"])
+	}
+
+	stream, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if _, err := stream.RecoverAll(); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestWithNullString(t *testing.T) {
+	data := createDBFWithMultipleFields()
+
+	// blank out AGE and BIRTHDATE of the first record
+	offset := 129 + 1 + 10
+	copy(data[offset:offset+11], "           ")
+
+	reader, err := New(bytes.NewReader(data), WithNullString(`\N`))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	if records[0].Data["AGE"] != `\N` {
+		t.Errorf("Expected '\\N' for blank AGE, got '%s'", records[0].Data["AGE"])
+	}
+	if records[0].Data["BIRTHDATE"] != `\N` {
+		t.Errorf("Expected '\\N' for blank BIRTHDATE, got '%s'", records[0].Data["BIRTHDATE"])
+	}
+	if records[0].Data["NAME"] == `\N` {
+		t.Error("Expected non-blank NAME to be kept")
+	}
+}
+
+func TestWithClipperNumericFormat(t *testing.T) {
+	writer, file := createTestWriter(t, []Field{
+		{Name: "SINGLE", Type: 'N', Length: 4},
+		{Name: "DOUBLE", Type: 'N', Length: 8, DecimalCount: 2},
+		{Name: "ASCII", Type: 'N', Length: 3},
+	})
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"ASCII": "42"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	offset := 32 + 32*3 + 1 + 1
+	binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(1.5))
+	binary.LittleEndian.PutUint64(data[offset+4:], math.Float64bits(1234.5))
+
+	reader, err := NewFromBytes(data, WithClipperNumericFormat())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+
+	expected := map[string]string{"SINGLE": "1.5", "DOUBLE": "1234.50", "ASCII": "42"}
+	for name, want := range expected {
+		if record.Data[name] != want {
+			t.Errorf("%s: expected '%s', got '%s'", name, want, record.Data[name])
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	date, err := reader.ParseDate("19990115")
+	if err != nil {
+		t.Fatalf("ParseDate() failed: %v", err)
+	}
+	if !date.Equal(time.Date(1999, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 1999-01-15, got %v", date)
+	}
+
+	if date, err := reader.ParseDate(""); err != nil || !date.IsZero() {
+		t.Errorf("Expected zero time for blank date, got %v, %v", date, err)
+	}
+
+	if _, err := reader.ParseDate("990115"); err == nil {
+		t.Error("Expected error for two-digit year without WithDateCentury")
+	}
+}
+
+func TestWithDateCentury(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithDateCentury(30))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"990115", time.Date(1999, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"300101", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"310101", time.Date(1931, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"00050620", time.Date(2005, 6, 20, 0, 0, 0, 0, time.UTC)},
+		{"19850620", time.Date(1985, 6, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		date, err := reader.ParseDate(tt.value)
+		if err != nil {
+			t.Errorf("ParseDate(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !date.Equal(tt.expected) {
+			t.Errorf("ParseDate(%q): expected %v, got %v", tt.value, tt.expected, date)
+		}
+	}
+}
+
+func TestDeletionBitmap(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	bitmap, err := reader.DeletionBitmap()
+	if err != nil {
+		t.Fatalf("DeletionBitmap() failed: %v", err)
+	}
+	if len(bitmap) != 2 || bitmap[0] || !bitmap[1] {
+		t.Errorf("Expected [false true], got %v", bitmap)
+	}
+
+	// iteration is not affected
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records, got %d", len(records))
+	}
+
+	stream, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if _, err := stream.DeletionBitmap(); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestRecordMarshalText(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+
+	text, err := record.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() failed: %v", err)
+	}
+	if string(text) != "Alice\t25\t19990115" {
+		t.Errorf("Expected 'Alice\\t25\\t19990115', got %q", text)
+	}
+
+	// records built by hand are ordered by field name, with special characters escaped
+	manual := &Record{Data: map[string]string{"B": "tab\there", "A": `back\slash`}}
+	text, err = manual.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() failed: %v", err)
+	}
+	if string(text) != `back\\slash`+"\t"+`tab\there` {
+		t.Errorf("Unexpected escaped text: %q", text)
+	}
+}
+
+func TestSkipRecords(t *testing.T) {
+	sources := map[string]func() io.Reader{
+		"seekable": func() io.Reader { return bytes.NewReader(createMinimalDBF()) },
+		"stream":   func() io.Reader { return streamOnly{bytes.NewReader(createMinimalDBF())} },
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			reader, err := New(source(), WithCP866())
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			if err := reader.SkipRecords(1); err != nil {
+				t.Fatalf("SkipRecords() failed: %v", err)
+			}
+
+			records, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() failed: %v", err)
+			}
+			if len(records) != 1 || records[0].Data["NAME"] != "Jane Smith" {
+				t.Errorf("Expected only 'Jane Smith' after skip, got %v", records)
+			}
+
+			if err := reader.SkipRecords(1); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("Expected ErrOutOfRange, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithAutoAlign(t *testing.T) {
+	data := createMinimalDBF()
+	binary.LittleEndian.PutUint16(data[10:12], 12) // off by one: fields require 11 bytes
+
+	// without the option the second record is misaligned
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, _ := reader.ReadAll()
+	if len(records) == 2 && records[1].Data["NAME"] == "Jane Smith" {
+		t.Fatal("Expected misaligned second record without WithAutoAlign")
+	}
+
+	reader, err = New(bytes.NewReader(data), WithCP866(), WithAutoAlign())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if reader.RecordSize() != 11 {
+		t.Errorf("Expected record size 11, got %d", reader.RecordSize())
+	}
+	if len(reader.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %v", reader.Warnings())
+	}
+
+	records, err = reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 || records[1].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected aligned records, got %v", records)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithContext(ctx))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, ok, err := reader.NextRecord(); !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+
+	cancel()
+
+	if _, _, err := reader.NextRecord(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if reader.Next() {
+		t.Error("Next() should return false after cancellation")
+	}
+	if !errors.Is(reader.Err(), context.Canceled) {
+		t.Errorf("Expected Err() to return context.Canceled, got %v", reader.Err())
+	}
+}
+
+func TestWithContextCheckInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the context is only checked on every second record
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(),
+		WithContext(ctx), WithContextCheckInterval(2))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadAll()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected 1 record before the check, got %d", len(records))
+	}
+}
+
+func TestReadAllContext(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadAllContext(context.Background())
+	if err != nil {
+		t.Fatalf("ReadAllContext() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records, got %d", len(records))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reader, err = New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err = reader.ReadAllContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Expected no records, got %d", len(records))
+	}
+
+	// the reader is not bound to the cancelled context
+	records, err = reader.ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Errorf("Expected ReadAll() to return 2 records, got %d, %v", len(records), err)
+	}
+}
+
+// createVFPDBFWithBacklink creates a Visual FoxPro table whose header
+// contains the 263-byte database container backlink after the terminator
+func createVFPDBFWithBacklink() []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(0x30)
+	buf.WriteByte(124)
+	buf.WriteByte(3)
+	buf.WriteByte(1)
+
+	binary.Write(buf, binary.LittleEndian, uint32(2))             // 2 records
+	binary.Write(buf, binary.LittleEndian, uint16(32+32*2+1+263)) // header with backlink
+	binary.Write(buf, binary.LittleEndian, uint16(1+10+3))        // record size
+	buf.Write(append(make([]byte, 16), 0x04, 0x03, 0x00, 0x00))   // flags: DBC table, LDID 0x03
+
+	writeField := func(name string, fieldType byte, length byte) {
+		buf.Write(append([]byte(name), make([]byte, 11-len(name))...))
+		buf.WriteByte(fieldType)
+		buf.Write(make([]byte, 4))
+		buf.WriteByte(length)
+		buf.Write(make([]byte, 15))
+	}
+	writeField("NAME", 'C', 10)
+	writeField("QTY", 'N', 3)
+	buf.WriteByte(0x0D)
+
+	backlink := make([]byte, 263)
+	copy(backlink, `..\sales.dbc`)
+	buf.Write(backlink)
+
+	buf.WriteString(" Widget     12")
+	buf.WriteString(" Gadget      7")
+	buf.WriteByte(0x1A)
+
+	return buf.Bytes()
+}
+
+func TestVFPBacklink(t *testing.T) {
+	reader, err := New(bytes.NewReader(createVFPDBFWithBacklink()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if reader.FieldsCount() != 2 {
+		t.Errorf("Expected 2 fields, got %d", reader.FieldsCount())
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "Widget" || records[0].Data["QTY"] != "12" {
+		t.Errorf("Unexpected first record %v", records[0].Data)
+	}
+	if records[1].Data["NAME"] != "Gadget" || records[1].Data["QTY"] != "7" {
+		t.Errorf("Unexpected second record %v", records[1].Data)
+	}
+
+	// random access uses the same data start
+	record, err := reader.ReadAt(1)
+	if err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if record.Data["NAME"] != "Gadget" {
+		t.Errorf("Expected 'Gadget', got '%s'", record.Data["NAME"])
+	}
+}
+
+func TestPipeRecords(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var names []string
+	err = reader.PipeRecords(context.Background(), func(rec *Record) error {
+		names = append(names, rec.Data["NAME"])
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("PipeRecords() failed: %v", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("Expected 2 records, got %d", len(names))
+	}
+}
+
+func TestPipeRecordsStop(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	calls := 0
+	err = reader.PipeRecords(context.Background(), func(rec *Record) error {
+		calls++
+		return ErrStopIteration
+	})
+	if err != nil {
+		t.Errorf("Expected nil error for ErrStopIteration, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+
+	errBad := errors.New("bad record")
+	err = reader.PipeRecords(context.Background(), func(rec *Record) error {
+		return errBad
+	})
+	if !errors.Is(err, errBad) {
+		t.Errorf("Expected errBad, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader, err = New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := reader.PipeRecords(ctx, func(*Record) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPipeRecordsWrappedStop(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	err = reader.PipeRecords(context.Background(), func(rec *Record) error {
+		return fmt.Errorf("found %s: %w", rec.Data["NAME"], ErrStopIteration)
+	})
+	if err != nil {
+		t.Errorf("Expected nil error for wrapped ErrStopIteration, got %v", err)
+	}
+}
+
+func TestInvalidLastUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		month, day byte
+	}{
+		{"month 13", 13, 1},
+		{"month 0", 0, 1},
+		{"day 0", 1, 0},
+		{"february 30", 2, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := createMinimalDBF()
+			data[2], data[3] = tt.month, tt.day
+
+			reader, err := New(bytes.NewReader(data), WithCP866())
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			if reader.HasLastUpdate() {
+				t.Error("Expected HasLastUpdate() to be false")
+			}
+			if !reader.LastUpdate().IsZero() {
+				t.Errorf("Expected zero LastUpdate, got %v", reader.LastUpdate())
+			}
+			if len(reader.Warnings()) != 1 {
+				t.Errorf("Expected 1 warning, got %v", reader.Warnings())
+			}
+		})
+	}
+
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.HasLastUpdate() {
+		t.Error("Expected HasLastUpdate() to be true for a valid date")
+	}
+}
+
+func TestTableFlags(t *testing.T) {
+	reader, err := New(bytes.NewReader(createVFPDBFWithBacklink()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if reader.TableFlags() != 0x04 {
+		t.Errorf("Expected table flags 0x04, got 0x%02X", reader.TableFlags())
+	}
+	if !reader.IsDBCTable() || reader.HasCDXIndex() || reader.HasMemoFile() {
+		t.Errorf("Expected only IsDBCTable() for flags 0x04")
+	}
+
+	data := createVFPDBFWithBacklink()
+	data[28] = 0x03
+	reader, err = New(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.HasCDXIndex() || !reader.HasMemoFile() || reader.IsDBCTable() {
+		t.Errorf("Expected HasCDXIndex() and HasMemoFile() for flags 0x03")
+	}
+}
+
+func TestTransactionAndEncryptionFlags(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if reader.IsEncrypted() || reader.InTransaction() {
+		t.Error("Expected no flags for a plain table")
+	}
+
+	data := createMinimalDBF()
+	data[14], data[15] = 0x01, 0x01
+
+	reader, err = New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.InTransaction() {
+		t.Error("Expected InTransaction() to be true")
+	}
+	if !reader.IsEncrypted() {
+		t.Error("Expected IsEncrypted() to be true")
+	}
+	if len(reader.Warnings()) != 1 || !strings.Contains(reader.Warnings()[0], "encrypted") {
+		t.Errorf("Expected an encryption warning, got %v", reader.Warnings())
+	}
+}
+
+// failingTransformer fails on any non-empty input
+type failingTransformer struct{ transform.NopResetter }
+
+func (failingTransformer) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	if len(src) == 0 {
+		return 0, 0, nil
+	}
+	return 0, 0, errors.New("invalid sequence")
+}
+
+func TestWithIgnoreDecodeErrors(t *testing.T) {
+	decoder := &encoding.Decoder{Transformer: failingTransformer{}}
+
+	// without the option the raw bytes are returned silently
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithDecoder(decoder))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Expected raw 'John Doe', got '%s'", records[0].Data["NAME"])
+	}
+	if len(reader.Warnings()) != 0 {
+		t.Errorf("Expected no warnings, got %v", reader.Warnings())
+	}
+
+	reader, err = New(bytes.NewReader(createMinimalDBF()), WithDecoder(decoder), WithIgnoreDecodeErrors())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(reader.Warnings()) != 2 || !strings.Contains(reader.Warnings()[0], "decode field NAME") {
+		t.Errorf("Expected 2 decode warnings, got %v", reader.Warnings())
+	}
+}
+
+func TestRecordGet(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+
+	for _, name := range []string{"NAME", "name", "Name"} {
+		if value, ok := record.Get(name); !ok || value != "Alice" {
+			t.Errorf("Get(%q): expected 'Alice', got %q, %v", name, value, ok)
+		}
+	}
+	if value, ok := record.Get("birthDate"); !ok || value != "19990115" {
+		t.Errorf("Get(birthDate): expected '19990115', got %q, %v", value, ok)
+	}
+	if _, ok := record.Get("missing"); ok {
+		t.Error("Get(missing): expected ok=false")
+	}
+}
+
+func TestRecordGetOrHas(t *testing.T) {
+	record := &Record{Data: map[string]string{"NAME": "Alice", "NOTE": ""}}
+
+	if !record.Has("NOTE") {
+		t.Error("Has(NOTE): expected true for empty value")
+	}
+	if record.Has("MISSING") {
+		t.Error("Has(MISSING): expected false")
+	}
+
+	if value := record.GetOr("NAME", "x"); value != "Alice" {
+		t.Errorf("GetOr(NAME): expected 'Alice', got %q", value)
+	}
+	if value := record.GetOr("NOTE", "x"); value != "" {
+		t.Errorf("GetOr(NOTE): expected empty value, got %q", value)
+	}
+	if value := record.GetOr("MISSING", "x"); value != "x" {
+		t.Errorf("GetOr(MISSING): expected default 'x', got %q", value)
+	}
+}
+
+func TestRecordString(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	if s := records[0].String(); s != `NAME="John Doe"` {
+		t.Errorf("Expected 'NAME=\"John Doe\"', got %s", s)
+	}
+	if s := fmt.Sprint(records[1]); s != `[*] NAME="Jane Smith"` {
+		t.Errorf("Expected '[*] NAME=\"Jane Smith\"', got %s", s)
+	}
+
+	record := &Record{Data: map[string]string{"QTY": "5", "CITY": "", "DESCRIPTION": "a,b"}}
+	if s := record.String(); s != `CITY="", DESCRIPTION="a,b", QTY=5` {
+		t.Errorf("Unexpected String() output: %s", s)
+	}
+
+	expected := "  CITY        : \n  DESCRIPTION : a,b\n  QTY         : 5\n"
+	if s := record.PrettyString("  "); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+	if s := records[1].PrettyString(""); !strings.HasPrefix(s, "[*]\n") {
+		t.Errorf("Expected deleted marker, got %q", s)
+	}
+}
+
+func TestLargeRecordsOneByteReader(t *testing.T) {
+	// 20 fields of 254 bytes: records larger than the default bufio buffer
+	fields := make([]Field, 20)
+	for i := range fields {
+		fields[i] = NewCharField(fmt.Sprintf("F%02d", i), 254)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, fields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		data := make(map[string]string, len(fields))
+		for _, field := range fields {
+			data[field.Name] = strings.Repeat(string(rune('a'+i)), 250) + field.Name[1:]
+		}
+		if err := writer.AppendRecord(&Record{Deleted: i == 1, Data: data}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	binary.LittleEndian.PutUint32(buf.Bytes()[4:8], 3) // bytes.Buffer is not seekable
+
+	reader, err := New(iotest.OneByteReader(bytes.NewReader(buf.Bytes())), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if reader.RecordSize() <= 4096 {
+		t.Fatalf("Expected record size above 4096, got %d", reader.RecordSize())
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	for i, letter := range []string{"a", "c"} {
+		for _, field := range fields {
+			want := strings.Repeat(letter, 250) + field.Name[1:]
+			if records[i].Data[field.Name] != want {
+				t.Fatalf("Record %d field %s: expected %q, got %q", i, field.Name, want, records[i].Data[field.Name])
+			}
+		}
+	}
+}
+
+func TestNewFromGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(createMinimalDBF()); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromGzip(writeTestFile(t, "data.dbf.gz", compressed.Bytes()), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromGzip() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Unexpected records %v", records)
+	}
+
+	if _, err := reader.ReadAt(0); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestNewFromGzipUncompressed(t *testing.T) {
+	reader, err := NewFromGzip(writeTestFile(t, "data.dbf.gz", createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromGzip() failed: %v", err)
+	}
+	defer reader.Close()
+
+	// plain files keep random access
+	record, err := reader.ReadAt(1)
+	if err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if record.Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got '%s'", record.Data["NAME"])
+	}
+}
+
+func TestWithTrimMode(t *testing.T) {
+	data := createMinimalDBF()
+	copy(data[66:76], "  John    ")
+
+	tests := []struct {
+		mode     TrimMode
+		expected string
+	}{
+		{TrimBoth, "John"},
+		{TrimTrailing, "  John"},
+		{TrimLeading, "John    "},
+		{TrimNone, "  John    "},
+	}
+
+	for _, tt := range tests {
+		reader, err := New(bytes.NewReader(data), WithCP866(), WithTrimMode(tt.mode))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		record, ok, err := reader.NextRecord()
+		if !ok || err != nil {
+			t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+		}
+		if record.Data["NAME"] != tt.expected {
+			t.Errorf("Mode %d: expected %q, got %q", tt.mode, tt.expected, record.Data["NAME"])
+		}
+	}
+}
+
+func TestReadWithRaw(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if !reader.Next() {
+		t.Fatal("Expected a record")
+	}
+	record, err := reader.ReadWithRaw()
+	if err != nil {
+		t.Fatalf("ReadWithRaw() failed: %v", err)
+	}
+
+	if record.Data["NAME"] != "Alice" {
+		t.Errorf("Expected trimmed 'Alice', got %q", record.Data["NAME"])
+	}
+	if raw := record.RawString("NAME"); raw != "Alice     " {
+		t.Errorf("Expected padded 'Alice     ', got %q", raw)
+	}
+	if raw := record.RawString("AGE"); raw != " 25" {
+		t.Errorf("Expected padded ' 25', got %q", raw)
+	}
+	if raw := record.RawString("MISSING"); raw != "" {
+		t.Errorf("Expected empty string for unknown field, got %q", raw)
+	}
+
+	// records from Read() carry no raw values
+	if err := reader.Rewind(); err != nil {
+		t.Fatalf("Rewind() failed: %v", err)
+	}
+	reader.Next()
+	record, err = reader.Read()
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if raw := record.RawString("NAME"); raw != "" {
+		t.Errorf("Expected no raw value from Read(), got %q", raw)
+	}
+}
+
+func TestReadN(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadN(1)
+	if err != nil {
+		t.Fatalf("ReadN() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NAME"] != "Alice" {
+		t.Fatalf("Expected Alice, got %v", records)
+	}
+
+	// the reader continues after the records already read
+	rest, err := reader.ReadN(100)
+	if err != nil {
+		t.Fatalf("ReadN() failed: %v", err)
+	}
+	if uint32(len(rest)) != reader.RecordsCount()-1 {
+		t.Errorf("Expected %d remaining records, got %d", reader.RecordsCount()-1, len(rest))
+	}
+	if len(rest) > 0 && rest[0].Data["NAME"] == "Alice" {
+		t.Error("Expected ReadN to continue after the first record")
+	}
+}
+
+func TestReadNSkipDeleted(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := reader.ReadN(2)
+	if err != nil {
+		t.Fatalf("ReadN() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Deleted {
+		t.Errorf("Expected 1 active record, got %v", records)
+	}
+}
+
+func TestWithVFPDefaultEncoding(t *testing.T) {
+	data := createVFPDBFWithBacklink()
+	data[29] = 0x00                                                      // no language driver
+	data = bytes.Replace(data, []byte("Widget"), []byte("Wid\x80et"), 1) // 0x80 is the euro sign in Windows-1252
+
+	reader, err := New(bytes.NewReader(data), WithVFPDefaultEncoding())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if len(reader.Warnings()) == 0 || !strings.Contains(reader.Warnings()[0], "Windows-1252") {
+		t.Errorf("Expected Windows-1252 warning, got %v", reader.Warnings())
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["NAME"] != "Wid€et" {
+		t.Errorf("Expected 'Wid€et', got %q", records[0].Data["NAME"])
+	}
+
+	// without the option ISO-8859-1 is assumed
+	reader, err = New(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !strings.Contains(reader.Warnings()[0], "ISO-8859-1") {
+		t.Errorf("Expected ISO-8859-1 warning, got %v", reader.Warnings())
+	}
+}
+
+func TestWithFieldAliases(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866(),
+		WithFieldAliases(map[string]string{"NAME": "FullName", "MISSING": "Nothing"}))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if reader.Fields()[0].Name != "NAME" {
+		t.Errorf("Expected original name 'NAME', got '%s'", reader.Fields()[0].Name)
+	}
+	aliased := reader.AliasedFields()
+	if aliased[0].Name != "FullName" || aliased[1].Name != "AGE" {
+		t.Errorf("Expected aliased names [FullName AGE ...], got %v", aliased)
+	}
+
+	warnings := reader.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "MISSING") {
+		t.Errorf("Expected warning about MISSING, got %v", warnings)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["FullName"] != "Alice" {
+		t.Errorf("Expected 'Alice' under alias, got %v", records[0].Data)
+	}
+	if _, ok := records[0].Data["NAME"]; ok {
+		t.Error("Expected original name not to be a key")
+	}
+
+	// typed getters accept the alias
+	age, ok, err := records[0].Int(reader, "AGE")
+	if err != nil || !ok || age != 25 {
+		t.Errorf("Expected AGE 25, got %d, %v, %v", age, ok, err)
+	}
+	name, _, err := records[0].Text(reader, "FullName")
+	if err != nil || name != "Alice" {
+		t.Errorf("Expected 'Alice', got %q, %v", name, err)
+	}
+}
+
+func TestTruncatedFieldDescriptors(t *testing.T) {
+	data := createDBFWithMultipleFields()
+
+	// cut the file in the middle of the second field descriptor
+	_, err := New(bytes.NewReader(data[:32+32+10]))
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("Expected ErrTruncated, got %v", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected wrapped io.ErrUnexpectedEOF, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "read field 1") {
+		t.Errorf("Expected error to name field 1, got %v", err)
+	}
+
+	// cut right after a complete descriptor
+	if _, err := New(bytes.NewReader(data[:32+32])); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected ErrTruncated, got %v", err)
+	}
+}
+
+func TestNewAt(t *testing.T) {
+	table := createDBFWithMultipleFields()
+	container := append(append([]byte("CONTAINER HEADER"), table...), []byte("TRAILER")...)
+
+	reader, err := NewAt(bytes.NewReader(container), 16, int64(len(table)), WithCP866())
+	if err != nil {
+		t.Fatalf("NewAt() failed: %v", err)
+	}
+
+	expected, err := New(bytes.NewReader(table), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	want, _ := expected.ReadAll()
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != len(want) || !records[0].Equal(want[0]) {
+		t.Fatalf("Expected %v, got %v", want, records)
+	}
+
+	// random access stays within the embedded table
+	last, err := reader.ReadAt(reader.RecordsCount() - 1)
+	if err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if !last.Equal(want[len(want)-1]) {
+		t.Errorf("Expected %v, got %v", want[len(want)-1], last)
+	}
+	if _, err := reader.ReadAt(reader.RecordsCount()); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+
+	if _, err := NewAt(bytes.NewReader(container), -1, 10); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+}
+
+func TestRecordHashes(t *testing.T) {
+	data := createMinimalDBF()
+
+	reader, err := New(streamOnly{bytes.NewReader(data)}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	before, err := reader.RecordHashes()
+	if err != nil {
+		t.Fatalf("RecordHashes() failed: %v", err)
+	}
+	if len(before) != 2 || before[0] == before[1] {
+		t.Fatalf("Expected 2 distinct hashes, got %v", before)
+	}
+
+	// change the second record only
+	changed := bytes.Clone(data)
+	copy(changed[len(changed)-10:], "Jane Doe  ")
+
+	reader, err = New(bytes.NewReader(changed), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	after, err := reader.RecordHashes()
+	if err != nil {
+		t.Fatalf("RecordHashes() failed: %v", err)
+	}
+	if before[0] != after[0] {
+		t.Error("Expected unchanged record to keep its hash")
+	}
+	if before[1] == after[1] {
+		t.Error("Expected changed record to get a new hash")
+	}
+
+	if _, err := reader.RecordHashes(); err == nil {
+		t.Error("Expected error when the reader is already positioned")
+	}
+}
+
+func TestWithFieldEncoding(t *testing.T) {
+	fields := []Field{NewCharField("DOS", 10), NewCharField("WIN", 10)}
+
+	// write both columns in CP866, then overwrite WIN with Windows-1251 bytes
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, fields, WithWriterEncoding(charmap.CodePage866))
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"DOS": "Привет"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	data := buf.Bytes()
+	win, _ := charmap.Windows1251.NewEncoder().Bytes([]byte("Мир"))
+	copy(data[len(data)-11:], win) // WIN is the last field before the end-of-file marker
+
+	reader, err := NewFromBytes(data, WithRecordCountFromFile(),
+		WithFieldEncoding("WIN", charmap.Windows1251))
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["DOS"] != "Привет" {
+		t.Errorf("Expected 'Привет', got %q", records[0].Data["DOS"])
+	}
+	if records[0].Data["WIN"] != "Мир" {
+		t.Errorf("Expected 'Мир', got %q", records[0].Data["WIN"])
+	}
+
+	if _, err := NewFromBytes(data, WithFieldEncoding("MISSING", charmap.Windows1251)); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	data := createMinimalDBF()
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	expected := Layout{HeaderSize: 65, RecordSize: 11, DataStartOffset: 65, DataSize: 22, EOFMarker: false}
+	if layout := reader.Layout(); layout != expected {
+		t.Errorf("Expected %+v, got %+v", expected, layout)
+	}
+
+	// with the end-of-file marker
+	reader, err = New(bytes.NewReader(append(data, 0x1A)), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.Layout().EOFMarker {
+		t.Error("Expected EOFMarker to be true")
+	}
+
+	// unknown size
+	reader, err = New(streamOnly{bytes.NewReader(data)}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.Layout().EOFMarker {
+		t.Error("Expected EOFMarker to be true when the size is unknown")
+	}
+}
+
+func TestHeaderAndFieldDescriptorBytes(t *testing.T) {
+	data := createDBFWithMultipleFields()
+	data[32+18] = 0x04 // VFP field flags, not modelled by Field
+
+	reader, err := New(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	header := reader.HeaderBytes()
+	if !bytes.Equal(header, data[:32]) {
+		t.Errorf("Expected header %X, got %X", data[:32], header)
+	}
+
+	descriptors := reader.FieldDescriptorBytes()
+	if !bytes.Equal(descriptors, data[32:32+3*32]) {
+		t.Errorf("Expected descriptors %X, got %X", data[32:32+3*32], descriptors)
+	}
+	if descriptors[18] != 0x04 {
+		t.Errorf("Expected field flags 0x04, got 0x%02X", descriptors[18])
+	}
+
+	// the returned slices are copies
+	header[0] = 0xFF
+	if reader.HeaderBytes()[0] != data[0] {
+		t.Error("Expected HeaderBytes to return a copy")
+	}
+}
+
+func TestReadNthRecord(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	record, err := reader.ReadNthRecord(1)
+	if err != nil {
+		t.Fatalf("ReadNthRecord() failed: %v", err)
+	}
+	if record.Data["NAME"] != "John Doe" {
+		t.Errorf("Expected 'John Doe', got '%s'", record.Data["NAME"])
+	}
+
+	// iteration continues after the fetched record
+	if !reader.Next() {
+		t.Fatal("Expected a record after ReadNthRecord")
+	}
+	record, err = reader.Read()
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if record.Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got '%s'", record.Data["NAME"])
+	}
+
+	if _, err := reader.ReadNthRecord(2); err != nil {
+		t.Fatalf("ReadNthRecord(2) failed: %v", err)
+	}
+	if reader.Next() {
+		t.Error("Expected no records after the last one")
+	}
+
+	for _, n := range []uint32{0, 3} {
+		if _, err := reader.ReadNthRecord(n); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("ReadNthRecord(%d): expected ErrOutOfRange, got %v", n, err)
+		}
+	}
+}
+
+func TestWithCustomDecoder(t *testing.T) {
+	data := createMinimalDBF()
+	data[32+11] = 'P' // proprietary field type
+
+	decoder := func(fieldType byte, data []byte) (string, error) {
+		if fieldType != 'P' {
+			t.Errorf("Expected field type 'P', got %q", fieldType)
+		}
+		if data[0] == 'J' && data[1] == 'a' {
+			return "", errors.New("unsupported value")
+		}
+		return fmt.Sprintf("%X", bytes.TrimSpace(data)), nil
+	}
+
+	reader, err := New(bytes.NewReader(data), WithCP866(), WithCustomDecoder(decoder))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	if want := fmt.Sprintf("%X", "John Doe"); records[0].Data["NAME"] != want {
+		t.Errorf("Expected %q, got %q", want, records[0].Data["NAME"])
+	}
+	// errors fall back to character decoding
+	if records[1].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got %q", records[1].Data["NAME"])
+	}
+}
+
+func TestWithReadToEOF(t *testing.T) {
+	data := createMinimalDBF()
+	binary.LittleEndian.PutUint32(data[4:8], 1) // header undercounts the records
+	data = append(data, 0x1A)
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected 1 record without WithReadToEOF, got %d", len(records))
+	}
+
+	// a partial record after the last complete one is ignored
+	data = append(data[:len(data)-1], " Partial"...)
+	reader, err = New(bytes.NewReader(data), WithCP866(), WithReadToEOF())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err = reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[1].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected 'Jane Smith', got '%s'", records[1].Data["NAME"])
+	}
+	if reader.RecordsCount() != 2 {
+		t.Errorf("Expected RecordsCount() 2, got %d", reader.RecordsCount())
+	}
+	if len(reader.Warnings()) != 2 {
+		t.Errorf("Expected 2 warnings, got %q", reader.Warnings())
+	}
+}
+
+// createWideDBF writes n records larger than the default 4096-byte read
+// buffer and sets the header record count to declared.
+func createWideDBF(t *testing.T, n int, declared uint32) []byte {
+	t.Helper()
+
+	fields := make([]Field, 17)
+	for i := range fields {
+		fields[i] = NewCharField(fmt.Sprintf("F%d", i+1), 254)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, fields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := writer.AppendRecord(&Record{Data: map[string]string{"F1": strconv.Itoa(i)}}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint32(data[4:8], declared)
+	return data
+}
+
+func TestWithReadToEOFWideRecords(t *testing.T) {
+	complete := createWideDBF(t, 3, 1)
+	partial := complete[:len(complete)-100] // last record cut, no EOF marker
+
+	sources := map[string]func([]byte) io.Reader{
+		"sized":  func(data []byte) io.Reader { return bytes.NewReader(data) },
+		"stream": func(data []byte) io.Reader { return streamOnly{bytes.NewReader(data)} },
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			for data, want := range map[*[]byte]int{&complete: 3, &partial: 2} {
+				reader, err := New(source(*data), WithReadToEOF())
+				if err != nil {
+					t.Fatalf("New() failed: %v", err)
+				}
+				records, err := reader.ReadAll()
+				if err != nil {
+					t.Fatalf("ReadAll() failed: %v", err)
+				}
+				if len(records) != want {
+					t.Errorf("Expected %d records, got %d", want, len(records))
+				}
+				for i, record := range records {
+					if record.Data["F1"] != strconv.Itoa(i) {
+						t.Errorf("Record %d: expected F1 %d, got %q", i, i, record.Data["F1"])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWithReadToEOFWriteFiltered(t *testing.T) {
+	reader, err := New(bytes.NewReader(createWideDBF(t, 3, 1)), WithReadToEOF())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.WriteFiltered(&buf); err != nil {
+		t.Fatalf("WriteFiltered() failed: %v", err)
+	}
+
+	copied, err := NewFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	if copied.RecordsCount() != 3 {
+		t.Errorf("Expected 3 records in the copy, got %d", copied.RecordsCount())
+	}
+}
+
+func TestWithReadToEOFMarker(t *testing.T) {
+	data := createMinimalDBF()
+	binary.LittleEndian.PutUint32(data[4:8], 1)
+	data = append(data, 0x1A)
+	data = append(data, " Trailing  "...) // garbage after the marker
+
+	reader, err := New(bytes.NewReader(data), WithCP866(), WithReadToEOF())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected reading to stop at the EOF marker after 2 records, got %d", len(records))
+	}
+}
+
+func TestDBase4SQLTable(t *testing.T) {
+	data := createMinimalDBF()
+	data[0] = byte(dBASEIVTF)
+	data[28] = 0x01    // production .mdx flag
+	data[32+31] = 0x01 // field has an index tag
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if !reader.HasMDXIndex() {
+		t.Error("Expected HasMDXIndex() to be true")
+	}
+	if !reader.Fields()[0].Indexed {
+		t.Error("Expected field NAME to be indexed")
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Expected 2 records starting with 'John Doe', got %v", records)
+	}
+
+	// the flags are not interpreted for other file types
+	data[0] = byte(FoxBASEPlusNoMemo)
+	reader, err = New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if reader.HasMDXIndex() || reader.Fields()[0].Indexed {
+		t.Error("Expected no index flags for a dBASE III table")
+	}
+}
+
+func TestInconsistentFieldLengths(t *testing.T) {
+	for length := 0; length <= 255; length += 5 {
+		for recordSize := 0; recordSize <= 20; recordSize++ {
+			data := createMinimalDBF()
+			data[32+16] = byte(length)
+			binary.LittleEndian.PutUint16(data[10:12], uint16(recordSize))
+			fits := 1+length <= recordSize
+
+			// every read path must report the error instead of panicking
+			reader, err := New(bytes.NewReader(data), WithCP866())
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			_, err = reader.ReadAll()
+			var fieldErr *FieldError
+			if !fits && !errors.As(err, &fieldErr) {
+				t.Errorf("length %d, record size %d: expected *FieldError from ReadAll(), got %v", length, recordSize, err)
+			}
+			if fits && errors.As(err, &fieldErr) {
+				t.Errorf("length %d, record size %d: unexpected error %v", length, recordSize, err)
+			}
+
+			reader, _ = New(bytes.NewReader(data), WithCP866(), WithPartialRecords())
+			if reader.Next() {
+				if record, err := reader.ReadWithRaw(); !fits && (record == nil || err == nil) {
+					t.Errorf("length %d, record size %d: expected a partial record and an error, got %v, %v", length, recordSize, record, err)
+				}
+			}
+
+			reader, _ = New(bytes.NewReader(data), WithCP866())
+			_, _ = reader.Column("NAME")
+			_, _ = reader.ReadAt(0)
+		}
+	}
+}
+
+// countingReaderAt records the ranges requested from the underlying data
+type countingReaderAt struct {
+	data  []byte
+	calls int
+	bytes int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.calls++
+	c.bytes += len(p)
+	return bytes.NewReader(c.data).ReadAt(p, off)
+}
+
+// streamOnly hides any io.ReaderAt/io.Seeker implementation of the wrapped reader
+type streamOnly struct {
+	r io.Reader
+}
+
+func (s streamOnly) Read(p []byte) (int, error) {
+	return s.r.Read(p)
+}
+
+func TestReadAt(t *testing.T) {
+	data := createMinimalDBF()
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	record, err := dbf.ReadAt(1)
+	if err != nil {
+		t.Fatalf("ReadAt(1) failed: %v", err)
+	}
+	if !record.Deleted {
+		t.Error("Record 1 should be deleted")
+	}
+	if record.Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected name 'Jane Smith', got '%s'", record.Data["NAME"])
+	}
+
+	// random access must not disturb sequential reading
+	records, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Sequential read after ReadAt returned unexpected records: %v", records)
+	}
+
+	if _, err := dbf.ReadAt(2); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestReadRange(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	records, err := dbf.ReadRange(0, 2)
+	if err != nil {
+		t.Fatalf("ReadRange() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "John Doe" || records[1].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Unexpected records: %v, %v", records[0].Data, records[1].Data)
+	}
+
+	if _, err := dbf.ReadRange(1, 2); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestReadAtNotSeekable(t *testing.T) {
+	dbf, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := dbf.ReadAt(0); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestNewFromRangeReaderAt(t *testing.T) {
+	data := createMinimalDBF()
+	ra := &countingReaderAt{data: data}
+
+	dbf, err := NewFromRangeReaderAt(ra, int64(len(data)), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromRangeReaderAt() failed: %v", err)
+	}
+
+	ra.calls, ra.bytes = 0, 0
+	record, err := dbf.ReadAt(1)
+	if err != nil {
+		t.Fatalf("ReadAt(1) failed: %v", err)
+	}
+	if record.Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected name 'Jane Smith', got '%s'", record.Data["NAME"])
+	}
+
+	// only the requested record should be fetched
+	if ra.calls != 1 || ra.bytes != 11 {
+		t.Errorf("Expected a single 11-byte range request, got %d calls for %d bytes", ra.calls, ra.bytes)
+	}
+}
+
+// Benchmark tests
+func BenchmarkNew(b *testing.B) {
+	data := createMinimalDBF()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader := bytes.NewReader(data)
+		_, err := New(reader, WithCP866())
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadAll(b *testing.B) {
+	data := createMinimalDBF()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader := bytes.NewReader(data)
+		dbf, _ := New(reader, WithCP866())
+		_, err := dbf.ReadAll()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNextRead(b *testing.B) {
+	data := createMinimalDBF()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader := bytes.NewReader(data)
+		dbf, _ := New(reader, WithCP866())
+		for dbf.Next() {
+			_, err := dbf.Read()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// createMostlyDeletedDBF creates a table of n records of about 8 KB each,
+// of which nine out of ten are marked as deleted
+func createMostlyDeletedDBF(tb testing.TB, n int) []byte {
+	tb.Helper()
+
+	fields := make([]Field, 32)
+	for i := range fields {
+		fields[i] = NewCharField(fmt.Sprintf("F%02d", i), 254)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, fields)
+	if err != nil {
+		tb.Fatalf("NewWriter() failed: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		rec := &Record{Deleted: i%10 != 0, Data: map[string]string{"F00": strconv.Itoa(i)}}
+		if err := writer.AppendRecord(rec); err != nil {
+			tb.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		tb.Fatalf("Close() failed: %v", err)
+	}
+
+	// bytes.Buffer is not seekable, so fix the record count
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint32(data[4:8], uint32(n))
+	return data
+}
+
+func TestSkipDeletedSeek(t *testing.T) {
+	data := createMostlyDeletedDBF(t, 100)
+
+	for name, src := range map[string]io.Reader{
+		"seekable": bytes.NewReader(data),
+		"stream":   streamOnly{bytes.NewReader(data)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reader, err := New(src, WithSkipDeleted())
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			records, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() failed: %v", err)
+			}
+			if len(records) != 10 {
+				t.Fatalf("Expected 10 active records, got %d", len(records))
+			}
+			for i, record := range records {
+				if want := strconv.Itoa(i * 10); record.Data["F00"] != want {
+					t.Errorf("Record %d: expected F00 %s, got %s", i, want, record.Data["F00"])
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSkipDeleted(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "deleted.dbf")
+	if err := os.WriteFile(path, createMostlyDeletedDBF(b, 1000), 0o644); err != nil {
+		b.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	run := func(b *testing.B, wrap func(*os.File) io.Reader) {
+		for i := 0; i < b.N; i++ {
+			file, err := os.Open(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+			dbf, _ := New(wrap(file), WithSkipDeleted())
+			if _, err := dbf.ReadAll(); err != nil {
+				b.Fatal(err)
+			}
+			file.Close()
+		}
+	}
+
+	b.Run("seek", func(b *testing.B) {
+		run(b, func(f *os.File) io.Reader { return f })
+	})
+	b.Run("discard", func(b *testing.B) {
+		run(b, func(f *os.File) io.Reader { return streamOnly{f} })
+	})
+}
+
+// createLargeDBF creates a DBF with n records of five fields: a 20-byte
+// character, a 10-digit numeric, a date, a logical and a 10-byte character
+func createLargeDBF(n int) []byte {
+	const recordSize = 1 + 20 + 10 + 8 + 1 + 10
+
+	buf := new(bytes.Buffer)
+	buf.Grow(32 + 32*5 + 1 + n*recordSize + 1)
+
+	buf.WriteByte(0x03)
+	buf.WriteByte(124)
+	buf.WriteByte(1)
+	buf.WriteByte(1)
+	binary.Write(buf, binary.LittleEndian, uint32(n))
+	binary.Write(buf, binary.LittleEndian, uint16(32+32*5+1))
+	binary.Write(buf, binary.LittleEndian, uint16(recordSize))
+	buf.Write(append(make([]byte, 17), 0x03, 0x00, 0x00)) // LDID 0x03
+
+	writeField := func(name string, fieldType byte, length byte) {
+		buf.Write(append([]byte(name), make([]byte, 11-len(name))...))
+		buf.WriteByte(fieldType)
+		buf.Write(make([]byte, 4))
+		buf.WriteByte(length)
+		buf.Write(make([]byte, 15))
+	}
+	writeField("NAME", 'C', 20)
+	writeField("AMOUNT", 'N', 10)
+	writeField("CREATED", 'D', 8)
+	writeField("ACTIVE", 'L', 1)
+	writeField("CODE", 'C', 10)
+	buf.WriteByte(0x0D)
+
+	for i := 0; i < n; i++ {
+		buf.WriteByte(0x20)
+		fmt.Fprintf(buf, "%-20s", fmt.Sprintf("Customer %d", i))
+		fmt.Fprintf(buf, "%10d", i*7)
+		fmt.Fprintf(buf, "2024%02d%02d", i%12+1, i%28+1)
+		buf.WriteByte("TF"[i%2])
+		fmt.Fprintf(buf, "%-10s", fmt.Sprintf("C%06d", i%1000000))
+	}
+	buf.WriteByte(0x1A)
+
+	return buf.Bytes()
+}
+
+func BenchmarkReadAllLarge(b *testing.B) {
+	data := createLargeDBF(100_000)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dbf, err := New(bytes.NewReader(data))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := dbf.ReadAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNextReadLarge(b *testing.B) {
+	data := createLargeDBF(100_000)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dbf, err := New(bytes.NewReader(data))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for dbf.Next() {
+			if _, err := dbf.Read(); err != nil {
 				b.Fatal(err)
 			}
 		}
+		if err := dbf.Err(); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
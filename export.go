@@ -0,0 +1,557 @@
+package dbf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxColumnWidth is the maximum number of characters PrintTable shows per value.
+const maxColumnWidth = 30
+
+// PrintTable reads up to limit records from the current position and writes
+// them to w as an aligned text table with the field names as headers, like
+// `head` for DBF files. A limit of 0 or less prints all remaining records.
+// Values longer than 30 characters are truncated with an ellipsis.
+// Deleted records are included unless the reader was created with WithSkipDeleted.
+//
+// Example:
+//
+//	reader.PrintTable(os.Stdout, 10)
+//
+//	NAME        AGE  BIRTHDATE
+//	----------  ---  ---------
+//	Alice       25   19990115
+func (r *Reader) PrintTable(w io.Writer, limit int) error {
+	header := make([]string, len(r.fields))
+	for i, field := range r.fields {
+		header[i] = field.Name
+	}
+	rows := [][]string{header}
+
+	for (limit <= 0 || len(rows) <= limit) && r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return fmt.Errorf("read record: %w", err)
+		}
+
+		row := make([]string, len(r.fields))
+		for i, field := range r.fields {
+			row[i] = tableCell(record.Data[r.dataKey(field)])
+		}
+		rows = append(rows, row)
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(value))
+		}
+	}
+
+	separator := make([]string, len(header))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		for i, value := range row {
+			if i > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(value)
+			if i < len(row)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	writeRow(header)
+	writeRow(separator)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// tableCell prepares a value for PrintTable: control characters are
+// replaced by spaces and long values are truncated with an ellipsis.
+func tableCell(value string) string {
+	value = strings.Map(func(c rune) rune {
+		if c < ' ' {
+			return ' '
+		}
+		return c
+	}, value)
+
+	if utf8.RuneCountInString(value) <= maxColumnWidth {
+		return value
+	}
+	return string([]rune(value)[:maxColumnWidth-1]) + "…"
+}
+
+// WriteNDJSON writes the remaining records to w as newline-delimited JSON,
+// one object per record with keys in field order, using the names set with
+// WithFieldAliases. Values are typed as by Column:
+// numbers for numeric and integer fields, booleans for logical fields,
+// "YYYY-MM-DD" strings for dates, and null for null (blank) values.
+// Deleted records are included unless the reader was created with WithSkipDeleted.
+//
+// Example:
+//
+//	{"NAME":"Alice","AGE":25,"BIRTHDATE":"1999-01-15","ACTIVE":true}
+func (r *Reader) WriteNDJSON(w io.Writer) error {
+	fields := r.AliasedFields()
+	bw := bufio.NewWriter(w)
+
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return fmt.Errorf("read record: %w", err)
+		}
+
+		bw.WriteByte('{')
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			name, _ := json.Marshal(field.Name)
+			value, err := json.Marshal(r.jsonValue(field, record.Data[field.Name]))
+			if err != nil {
+				return fmt.Errorf("encode field %s: %w", field.Name, err)
+			}
+			bw.Write(name)
+			bw.WriteByte(':')
+			bw.Write(value)
+		}
+		bw.WriteString("}\n")
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// jsonValue converts the string value of a field to the value used in JSON
+// output, using the types of Column. Values that cannot be converted are
+// kept as strings.
+func (r *Reader) jsonValue(field Field, value string) any {
+	if number, ok := r.numericText(field, value); ok {
+		return json.Number(number)
+	}
+
+	typed, err := r.columnValue(field, value)
+	if err != nil {
+		return value
+	}
+	if t, ok := typed.(time.Time); ok {
+		return t.Format("2006-01-02")
+	}
+	return typed
+}
+
+// WriteSQL writes the remaining records to w as SQL INSERT statements, one
+// per line, into the named table, for one-shot migrations to a database:
+//
+//	INSERT INTO "customers" ("NAME", "AGE", "BIRTHDATE", "ACTIVE") VALUES ('O''Brien', 25, '1999-01-15', TRUE);
+//
+// Values are converted like Column does: numbers are written unquoted, with
+// numeric (N, F) values copied digit for digit, dates
+// as 'YYYY-MM-DD', logicals as TRUE or FALSE and null (blank) values as NULL;
+// strings are quoted with embedded single quotes doubled. Values that cannot
+// be converted are written as strings. The table and column names are quoted
+// as identifiers ("NAME"), so reserved words such as DATE or ORDER are safe.
+// Deleted records are included unless the reader was created with WithSkipDeleted.
+//
+// Example:
+//
+//	out, _ := os.Create("customers.sql")
+//	defer out.Close()
+//	err := reader.WriteSQL(out, "customers")
+func (r *Reader) WriteSQL(w io.Writer, table string) error {
+	fields := r.AliasedFields()
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = sqlIdentifier(field.Name)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", sqlIdentifier(table), strings.Join(names, ", "))
+
+	bw := bufio.NewWriter(w)
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return fmt.Errorf("read record: %w", err)
+		}
+
+		bw.WriteString(prefix)
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteString(", ")
+			}
+			bw.WriteString(r.sqlValue(field, record.Data[field.Name]))
+		}
+		bw.WriteString(");\n")
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// sqlIdentifier quotes name as a SQL identifier, doubling embedded quotes.
+func sqlIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlValue converts the string value of a field to a SQL literal.
+func (r *Reader) sqlValue(field Field, value string) string {
+	if number, ok := r.numericText(field, value); ok {
+		return number
+	}
+
+	typed, err := r.columnValue(field, value)
+	if err != nil {
+		typed = value
+	}
+
+	switch v := typed.(type) {
+	case nil:
+		return "NULL"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.Format("'2006-01-02'")
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+	}
+}
+
+// numericText returns the value of a numeric (N, F) field as decimal text
+// for export. The text is kept rather than converted to float64, which
+// cannot hold more than about 15 significant digits.
+func (r *Reader) numericText(field Field, value string) (string, bool) {
+	if field.Type != 'N' && field.Type != 'F' {
+		return "", false
+	}
+	number := strings.TrimSpace(value)
+	if _, ok, err := r.ParseNumeric(number); !ok || err != nil {
+		return "", false
+	}
+	return decimalText(number), true
+}
+
+// decimalText completes the dBASE shorthands ".5" and "5." to "0.5" and
+// "5", keeping every digit of the decimal number s, so that it is valid in
+// SQL and JSON.
+func decimalText(s string) string {
+	sign := ""
+	if s[0] == '-' || s[0] == '+' {
+		sign, s = s[:1], s[1:]
+	}
+	if strings.HasPrefix(s, ".") {
+		s = "0" + s
+	}
+	return sign + strings.TrimSuffix(s, ".")
+}
+
+// WriteTo implements io.WriterTo. It copies the DBF file to w: the header as
+// stored, the raw bytes of every record as it is read from the source
+// (including deleted records), and the end-of-file marker. Combined with
+// io.Pipe, a table can be streamed to another destination while it is read.
+// It returns the number of bytes written.
+//
+// WriteTo must be called before any record is read, and leaves the reader
+// positioned after the last record.
+// With WithReadToEOF, records past the header count are copied too.
+//
+// Example:
+//
+//	reader, _ := dbf.NewFromFile("data.dbf")
+//	w.Header().Set("Content-Type", "application/x-dbf")
+//	_, err := reader.WriteTo(w)
+func (r *Reader) WriteTo(w io.Writer) (int64, error) {
+	if r.segments != nil {
+		return 0, errors.New("write to: not supported for multi-file readers")
+	}
+	if r.currentRecord != 0 {
+		return 0, fmt.Errorf("write to: reader is already positioned at record %d", r.currentRecord)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	written, err := w.Write(r.header)
+	total := int64(written)
+	if err != nil {
+		return total, err
+	}
+
+	for r.err == nil && r.hasMoreRecords() {
+		record, err := r.readRecordBytes()
+		if err != nil {
+			return total, err
+		}
+		r.currentRecord++
+
+		written, err := w.Write(record)
+		total += int64(written)
+		if err != nil {
+			return total, err
+		}
+	}
+
+	written, err = w.Write([]byte{0x1A})
+	total += int64(written)
+	return total, err
+}
+
+// WriteFiltered copies the DBF file to w like WriteTo, but omits records
+// rejected by the WithFilter predicate and, if the reader was created with
+// WithSkipDeleted, deleted records. The record count in the written header
+// is updated to the number of records kept, so this packs and filters a
+// table in a single pass. Kept records are copied byte for byte.
+//
+// If w implements io.WriteSeeker the records are streamed and the record
+// count is patched afterwards; otherwise they are buffered in memory until
+// the count is known.
+//
+// WriteFiltered must be called before any record is read, and leaves the
+// reader positioned after the last record.
+//
+// Example:
+//
+//	reader, _ := dbf.NewFromFile("orders.dbf", dbf.WithSkipDeleted(),
+//		dbf.WithFilter(func(rec *dbf.Record) bool { return rec.Data["STATUS"] == "A" }))
+//	out, _ := os.Create("active.dbf")
+//	defer out.Close()
+//	if err := reader.WriteFiltered(out); err != nil {
+//		log.Fatal(err)
+//	}
+func (r *Reader) WriteFiltered(w io.Writer) error {
+	if r.segments != nil {
+		return errors.New("write filtered: not supported for multi-file readers")
+	}
+	if r.currentRecord != 0 {
+		return fmt.Errorf("write filtered: reader is already positioned at record %d", r.currentRecord)
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	header := append([]byte(nil), r.header...)
+	ws, seekable := w.(io.WriteSeeker)
+	var headerStart int64
+	if seekable {
+		var err error
+		if headerStart, err = ws.Seek(0, io.SeekCurrent); err != nil {
+			return fmt.Errorf("seek: %w", err)
+		}
+	}
+
+	// without seeking the header can only be written once the count is known
+	var body bytes.Buffer
+	out := io.Writer(&body)
+	if seekable {
+		if _, err := w.Write(header); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+		out = w
+	}
+
+	var kept uint32
+	for r.err == nil && r.hasMoreRecords() {
+		record, err := r.readRecordBytes()
+		if err != nil {
+			return err
+		}
+		r.currentRecord++
+
+		if r.skipDeleted && len(record) > 0 && record[0] == 0x2A {
+			continue
+		}
+		if r.filter != nil {
+			parsed, err := r.parseRecord(record)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", r.currentRecord-1, err)
+			}
+			if !r.filter(parsed) {
+				continue
+			}
+		}
+
+		if _, err := out.Write(record); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+		kept++
+	}
+
+	if _, err := out.Write([]byte{0x1A}); err != nil {
+		return fmt.Errorf("write end-of-file marker: %w", err)
+	}
+
+	binary.LittleEndian.PutUint32(header[4:8], kept)
+	if !seekable {
+		if _, err := w.Write(header); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+		if _, err := body.WriteTo(w); err != nil {
+			return fmt.Errorf("write records: %w", err)
+		}
+		return nil
+	}
+
+	end, err := ws.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("seek: %w", err)
+	}
+	if _, err := ws.Seek(headerStart+4, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to record count: %w", err)
+	}
+	if _, err := ws.Write(header[4:8]); err != nil {
+		return fmt.Errorf("write record count: %w", err)
+	}
+	if _, err := ws.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to end: %w", err)
+	}
+
+	return nil
+}
+
+// CSVOption configures ToCSV.
+type CSVOption func(*csvConfig)
+
+// csvConfig holds the settings of ToCSV.
+type csvConfig struct {
+	separator          rune
+	skipDeleted        bool
+	trueValue          string
+	falseValue         string
+	includeDeletedFlag bool
+}
+
+// WithCSVSeparator sets the field separator, ',' by default.
+func WithCSVSeparator(separator rune) CSVOption {
+	return func(c *csvConfig) {
+		c.separator = separator
+	}
+}
+
+// WithCSVSkipDeleted omits records marked as deleted.
+func WithCSVSkipDeleted() CSVOption {
+	return func(c *csvConfig) {
+		c.skipDeleted = true
+	}
+}
+
+// WithCSVBoolFormat sets how logical values are written, "true" and "false"
+// by default. Null logical values are written as empty strings.
+func WithCSVBoolFormat(trueValue, falseValue string) CSVOption {
+	return func(c *csvConfig) {
+		c.trueValue = trueValue
+		c.falseValue = falseValue
+	}
+}
+
+// WithCSVIncludeDeletedFlag adds a last column named _deleted holding the
+// deletion flag of each record, formatted like logical values.
+func WithCSVIncludeDeletedFlag() CSVOption {
+	return func(c *csvConfig) {
+		c.includeDeletedFlag = true
+	}
+}
+
+// ToCSV writes the remaining records to w as CSV, with a header row of field
+// names followed by one row per record. Records are converted one at a time,
+// so files of any size can be converted with constant memory.
+// Deleted records are included unless WithCSVSkipDeleted is given or the
+// reader was created with WithSkipDeleted.
+//
+// Example:
+//
+//	out, _ := os.Create("data.csv")
+//	defer out.Close()
+//	err := reader.ToCSV(out, dbf.WithCSVSeparator(';'), dbf.WithCSVBoolFormat("Y", "N"))
+func (r *Reader) ToCSV(w io.Writer, opts ...CSVOption) error {
+	config := csvConfig{separator: ',', trueValue: "true", falseValue: "false"}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = config.separator
+
+	fields := r.AliasedFields()
+	row := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		row = append(row, field.Name)
+	}
+	if config.includeDeletedFlag {
+		row = append(row, "_deleted")
+	}
+	if err := cw.Write(row); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	formatBool := func(b bool) string {
+		if b {
+			return config.trueValue
+		}
+		return config.falseValue
+	}
+
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return err
+		}
+		if config.skipDeleted && record.Deleted {
+			continue
+		}
+
+		row = row[:0]
+		for _, field := range fields {
+			value := record.Data[field.Name]
+			if field.Type == 'L' && (value == "true" || value == "false") {
+				value = formatBool(value == "true")
+			}
+			row = append(row, value)
+		}
+		if config.includeDeletedFlag {
+			row = append(row, formatBool(record.Deleted))
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write record %d: %w", r.currentRecord-1, err)
+		}
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,423 @@
+package dbf
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintTable(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.PrintTable(&buf, 0); err != nil {
+		t.Fatalf("PrintTable() failed: %v", err)
+	}
+
+	expected := "NAME\n" +
+		"----------\n" +
+		"John Doe\n" +
+		"Jane Smith\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestPrintTableLimitAndSkipDeleted(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithMultipleFields()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.PrintTable(&buf, 1); err != nil {
+		t.Fatalf("PrintTable() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), lines)
+	}
+	if lines[0] != "NAME   AGE  BIRTHDATE" {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	if lines[2] != "Alice  25   19990115" {
+		t.Errorf("Unexpected row %q", lines[2])
+	}
+
+	reader, err = New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	buf.Reset()
+	if err := reader.PrintTable(&buf, 10); err != nil {
+		t.Fatalf("PrintTable() failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "Jane Smith") {
+		t.Error("Expected deleted record to be skipped")
+	}
+}
+
+func TestTableCellTruncation(t *testing.T) {
+	cell := tableCell(strings.Repeat("я", 40))
+	if cell != strings.Repeat("я", 29)+"…" {
+		t.Errorf("Unexpected truncated cell %q", cell)
+	}
+	if tableCell("a\tb") != "a b" {
+		t.Errorf("Expected tab to be replaced, got %q", tableCell("a\tb"))
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, testWriterFields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	records := []*Record{
+		{Data: map[string]string{"NAME": "Alice", "AGE": "25", "BIRTHDATE": "19990115", "ACTIVE": "T"}},
+		{Data: map[string]string{"NAME": "Bob \"B\"", "AGE": ".5"}},
+		{Deleted: true, Data: map[string]string{"NAME": "Gone"}},
+	}
+	for _, rec := range records {
+		if err := writer.AppendRecord(rec); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON() failed: %v", err)
+	}
+
+	expected := `{"NAME":"Alice","AGE":25,"BIRTHDATE":"1999-01-15","ACTIVE":true}` + "\n" +
+		`{"NAME":"Bob \"B\"","AGE":0.5,"BIRTHDATE":null,"ACTIVE":null}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteNDJSONAliases(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, []Field{NewCharField("NM", 10), NewNumericField("AMT", 4, 0)})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	for _, data := range []map[string]string{{"NM": "Alice", "AMT": "12"}, {"NM": "Bob", "AMT": "****"}} {
+		if err := writer.AppendRecord(&Record{Data: data}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile(),
+		WithFieldAliases(map[string]string{"NM": "name", "AMT": "amount"}))
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON() failed: %v", err)
+	}
+	expected := `{"name":"Alice","amount":12}` + "\n" + `{"name":"Bob","amount":null}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteNDJSONLargeNumbers(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, []Field{NewNumericField("ID", 20, 0), NewNumericField("RATE", 6, 2)})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"ID": "12345678901234567891", "RATE": ".50"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := reader.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON() failed: %v", err)
+	}
+
+	expected := `{"ID":12345678901234567891,"RATE":0.50}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteSQL(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, testWriterFields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	records := []*Record{
+		{Data: map[string]string{"NAME": "O'Brien", "AGE": "25", "BIRTHDATE": "19990115", "ACTIVE": "T"}},
+		{Data: map[string]string{"NAME": "Bob", "AGE": ".5", "ACTIVE": "F"}},
+		{Deleted: true, Data: map[string]string{"NAME": "Gone"}},
+	}
+	for _, rec := range records {
+		if err := writer.AppendRecord(rec); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.WriteSQL(&buf, "people"); err != nil {
+		t.Fatalf("WriteSQL() failed: %v", err)
+	}
+
+	expected := `INSERT INTO "people" ("NAME", "AGE", "BIRTHDATE", "ACTIVE") VALUES` + " ('O''Brien', 25, '1999-01-15', TRUE);\n" +
+		`INSERT INTO "people" ("NAME", "AGE", "BIRTHDATE", "ACTIVE") VALUES` + " ('Bob', 0.5, NULL, FALSE);\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteSQLLargeNumbers(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, []Field{NewNumericField("ID", 20, 0), NewNumericField("RATE", 6, 2)})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	for _, data := range []map[string]string{
+		{"ID": "12345678901234567891", "RATE": "1.10"},
+		{"ID": "1", "RATE": "******"},
+	} {
+		if err := writer.AppendRecord(&Record{Data: data}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := reader.WriteSQL(&buf, "t"); err != nil {
+		t.Fatalf("WriteSQL() failed: %v", err)
+	}
+
+	// overflowed values are lost and written as NULL
+	expected := `INSERT INTO "t" ("ID", "RATE") VALUES` + " (12345678901234567891, 1.10);\n" +
+		`INSERT INTO "t" ("ID", "RATE") VALUES` + " (1, NULL);\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteSQLQuotedIdentifiers(t *testing.T) {
+	var src bytes.Buffer
+	writer, err := NewWriter(&src, []Field{NewCharField("ORDER", 5), NewDateField("DATE")})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"ORDER": "A1", "DATE": "20240102"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(src.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := reader.WriteSQL(&buf, `my"table`); err != nil {
+		t.Fatalf("WriteSQL() failed: %v", err)
+	}
+
+	expected := `INSERT INTO "my""table" ("ORDER", "DATE") VALUES ('A1', '2024-01-02');` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	data := createMinimalDBF()
+
+	reader, err := New(streamOnly{bytes.NewReader(data)}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	var writerTo io.WriterTo = reader
+	n, err := writerTo.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+	expected := append(data, 0x1A) // the fixture has no end-of-file marker
+	if n != int64(len(expected)) {
+		t.Errorf("Expected %d bytes, got %d", len(expected), n)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Error("Copied file differs from the original")
+	}
+
+	// the copy is a valid table
+	copied, err := NewFromBytes(buf.Bytes(), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	records, err := copied.ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Errorf("Expected 2 records in copy, got %d, %v", len(records), err)
+	}
+
+	if _, err := copied.WriteTo(&buf); err == nil {
+		t.Error("Expected error when the reader is already positioned")
+	}
+}
+
+func TestWriteFiltered(t *testing.T) {
+	data := createMinimalDBF()
+
+	reader, err := New(bytes.NewReader(data), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.WriteFiltered(&buf); err != nil {
+		t.Fatalf("WriteFiltered() failed: %v", err)
+	}
+
+	packed, err := NewFromBytes(buf.Bytes(), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	if packed.RecordsCount() != 1 {
+		t.Errorf("Expected 1 record in header, got %d", packed.RecordsCount())
+	}
+	records, err := packed.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NAME"] != "John Doe" {
+		t.Errorf("Expected only John Doe, got %v", records)
+	}
+}
+
+func TestWriteFilteredWithFilter(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(),
+		WithFilter(func(rec *Record) bool { return strings.HasPrefix(rec.Data["NAME"], "Jane") }))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// a file exercises the seek-and-patch path
+	file, err := os.Create(filepath.Join(t.TempDir(), "filtered.dbf"))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	defer file.Close()
+
+	if err := reader.WriteFiltered(file); err != nil {
+		t.Fatalf("WriteFiltered() failed: %v", err)
+	}
+
+	filtered, err := NewFromFile(file.Name(), WithCP866())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer filtered.Close()
+
+	records, err := filtered.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if filtered.RecordsCount() != 1 || len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d (header %d)", len(records), filtered.RecordsCount())
+	}
+	// deleted records are kept without WithSkipDeleted
+	if !records[0].Deleted || records[0].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected deleted Jane Smith, got %+v", records[0])
+	}
+
+	if err := reader.WriteFiltered(io.Discard); err == nil {
+		t.Error("Expected error when the reader is already positioned")
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reader.ToCSV(&buf); err != nil {
+		t.Fatalf("ToCSV() failed: %v", err)
+	}
+	expected := "NAME\nJohn Doe\nJane Smith\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestToCSVOptions(t *testing.T) {
+	reader, err := New(bytes.NewReader(createDBFWithLogicalFields([]byte("TF "))), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = reader.ToCSV(&buf, WithCSVSeparator(';'), WithCSVBoolFormat("Y", "N"), WithCSVIncludeDeletedFlag())
+	if err != nil {
+		t.Fatalf("ToCSV() failed: %v", err)
+	}
+	expected := "ACTIVE;_deleted\nY;N\nN;N\n;N\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	reader, err = New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	buf.Reset()
+	if err := reader.ToCSV(&buf, WithCSVSkipDeleted()); err != nil {
+		t.Fatalf("ToCSV() failed: %v", err)
+	}
+	if buf.String() != "NAME\nJohn Doe\n" {
+		t.Errorf("Expected only the active record, got %q", buf.String())
+	}
+}
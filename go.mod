@@ -2,4 +2,4 @@ module github.com/demen1n/dbf
 
 go 1.25
 
-require golang.org/x/text v0.33.0 // indirect
+require golang.org/x/text v0.33.0
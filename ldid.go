@@ -0,0 +1,85 @@
+package dbf
+
+import (
+	"slices"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// LanguageDriver describes a Language Driver ID (header byte 29) that is
+// recognized for automatic encoding detection.
+type LanguageDriver struct {
+	ID          byte   // Language Driver ID
+	Name        string // code page name, e.g. "cp866"
+	Description string // human-readable description, e.g. "Russian MS-DOS"
+}
+
+// languageDriverEntry maps a Language Driver ID to its encoding.
+type languageDriverEntry struct {
+	LanguageDriver
+	encoding encoding.Encoding
+}
+
+// languageDrivers lists the supported Language Driver IDs, sorted by ID.
+var languageDrivers = []languageDriverEntry{
+	{LanguageDriver{0x00, "iso-8859-1", "No language driver (ISO-8859-1 assumed)"}, charmap.ISO8859_1},
+	{LanguageDriver{0x01, "cp437", "US MS-DOS"}, charmap.CodePage437},
+	{LanguageDriver{0x02, "cp850", "International MS-DOS"}, charmap.CodePage850},
+	{LanguageDriver{0x03, "cp1252", "Windows ANSI"}, charmap.Windows1252},
+	{LanguageDriver{0x13, "cp932", "Japanese Shift-JIS"}, japanese.ShiftJIS},
+	{LanguageDriver{0x26, "cp866", "Russian MS-DOS"}, charmap.CodePage866},
+	{LanguageDriver{0x4D, "cp936", "Simplified Chinese GBK"}, simplifiedchinese.GBK},
+	{LanguageDriver{0x4E, "cp949", "Korean"}, korean.EUCKR},
+	{LanguageDriver{0x4F, "cp950", "Traditional Chinese Big5"}, traditionalchinese.Big5},
+	{LanguageDriver{0x64, "cp1251", "Russian Windows"}, charmap.Windows1251},
+	{LanguageDriver{0x65, "cp1251", "Russian Windows"}, charmap.Windows1251},
+	{LanguageDriver{0x78, "cp950", "Traditional Chinese Big5 (Windows)"}, traditionalchinese.Big5},
+	{LanguageDriver{0x79, "cp949", "Korean (Windows)"}, korean.EUCKR},
+	{LanguageDriver{0x7A, "cp936", "Simplified Chinese GBK (Windows)"}, simplifiedchinese.GBK},
+	{LanguageDriver{0x7B, "cp932", "Japanese Shift-JIS (Windows)"}, japanese.ShiftJIS},
+	{LanguageDriver{0xC9, "cp1251", "Russian Windows"}, charmap.Windows1251},
+}
+
+// LanguageDrivers returns all Language Driver IDs recognized for automatic
+// encoding detection, sorted by ID.
+func LanguageDrivers() []LanguageDriver {
+	drivers := make([]LanguageDriver, len(languageDrivers))
+	for i, entry := range languageDrivers {
+		drivers[i] = entry.LanguageDriver
+	}
+	return drivers
+}
+
+// LanguageDriverByID returns the description of a Language Driver ID.
+// It returns false if the ID is not recognized.
+func LanguageDriverByID(id byte) (LanguageDriver, bool) {
+	i, ok := findLanguageDriver(id)
+	if !ok {
+		return LanguageDriver{}, false
+	}
+	return languageDrivers[i].LanguageDriver, true
+}
+
+// findLanguageDriver returns the index of id in languageDrivers.
+func findLanguageDriver(id byte) (int, bool) {
+	return slices.BinarySearchFunc(languageDrivers, id, func(entry languageDriverEntry, id byte) int {
+		return int(entry.ID) - int(id)
+	})
+}
+
+// getDecoderByLDID returns an appropriate text decoder based on the
+// Language Driver ID byte from the DBF header.
+// LDID 0x00 (no language driver) is treated as ISO-8859-1.
+// Returns nil if the Language Driver ID is not recognized.
+func getDecoderByLDID(ldid byte) *encoding.Decoder {
+	i, ok := findLanguageDriver(ldid)
+	if !ok {
+		return nil
+	}
+	return languageDrivers[i].encoding.NewDecoder()
+}
@@ -0,0 +1,41 @@
+package dbf
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLanguageDrivers(t *testing.T) {
+	drivers := LanguageDrivers()
+	if len(drivers) == 0 {
+		t.Fatal("LanguageDrivers() returned no entries")
+	}
+
+	if !slices.IsSortedFunc(drivers, func(a, b LanguageDriver) int { return int(a.ID) - int(b.ID) }) {
+		t.Error("Expected drivers sorted by ID")
+	}
+
+	// every listed driver selects an encoding
+	for _, driver := range drivers {
+		if getDecoderByLDID(driver.ID) == nil {
+			t.Errorf("LDID 0x%02X is listed but has no decoder", driver.ID)
+		}
+		if driver.Name == "" || driver.Description == "" {
+			t.Errorf("LDID 0x%02X has no name or description", driver.ID)
+		}
+	}
+}
+
+func TestLanguageDriverByID(t *testing.T) {
+	driver, ok := LanguageDriverByID(0x26)
+	if !ok {
+		t.Fatal("LanguageDriverByID(0x26) not found")
+	}
+	if driver.Name != "cp866" || driver.Description != "Russian MS-DOS" {
+		t.Errorf("Unexpected driver %+v", driver)
+	}
+
+	if _, ok := LanguageDriverByID(0xFF); ok {
+		t.Error("LanguageDriverByID(0xFF) should not be found")
+	}
+}
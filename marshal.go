@@ -0,0 +1,344 @@
+package dbf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marshal and Unmarshal map the exported fields of a struct to record values
+// using struct tags, in the style of encoding/json:
+//
+//	type Customer struct {
+//		Name    string    `dbf:"NAME"`
+//		Balance float64   `dbf:"BALANCE,omitempty"`
+//		Since   time.Time `dbf:"SINCE"`
+//		Active  bool      `dbf:"ACTIVE"`
+//		Notes   string    `dbf:"-"` // ignored
+//	}
+//
+// Untagged fields use the upper-cased Go field name. Supported field types are
+// string, signed and unsigned integers, floats, bool and time.Time; values are
+// formatted as returned by Reader.Read ("true"/"false" for logicals,
+// YYYYMMDD for dates).
+
+// structField describes a struct field mapped to a DBF field.
+type structField struct {
+	index     int
+	name      string
+	omitEmpty bool
+	options   map[string]string // key=value tag options
+}
+
+// structFields returns the mapped fields of a struct type.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("dbf")
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := structField{index: i, name: parts[0], options: make(map[string]string)}
+		if field.name == "" {
+			field.name = strings.ToUpper(sf.Name)
+		}
+		for _, option := range parts[1:] {
+			if option == "omitempty" {
+				field.omitEmpty = true
+				continue
+			}
+			if key, value, ok := strings.Cut(option, "="); ok {
+				field.options[key] = value
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// structValue returns the struct value v points to (or is).
+func structValue(v any, needPointer bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	} else if needPointer {
+		return reflect.Value{}, errors.New("expected a non-nil pointer to a struct")
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a struct, got %s", rv.Kind())
+	}
+	return rv, nil
+}
+
+// Marshal converts the struct v (or a pointer to it) to a record, formatting
+// every mapped field as a string. With the omitempty option, a zero string,
+// time or bool is stored as an empty string, which the Writer writes as a
+// blank field; numbers are always written, so zero is written as 0.
+// Floats are written with the number of decimals given by the decimals tag
+// option, matching the field definition of NewWriterFromStruct, and with
+// the shortest exact representation otherwise.
+//
+// Example:
+//
+//	rec, err := dbf.Marshal(Customer{Name: "Alice", Since: time.Now()})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = writer.AppendRecord(rec)
+func Marshal(v any) (*Record, error) {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	fields := structFields(rv.Type())
+	rec := &Record{Data: make(map[string]string, len(fields))}
+	for _, field := range fields {
+		fv := rv.Field(field.index)
+		if field.omitEmpty && fv.IsZero() && !isNumberKind(fv.Kind()) {
+			rec.Data[field.name] = ""
+			continue
+		}
+
+		value, err := field.format(fv)
+		if err != nil {
+			return nil, fmt.Errorf("marshal field %s: %w", field.name, err)
+		}
+		rec.Data[field.name] = value
+	}
+
+	return rec, nil
+}
+
+// isNumberKind reports whether k is an integer or floating-point kind.
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// format formats the value v of the struct field as a record value.
+func (sf structField) format(v reflect.Value) (string, error) {
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format("20060102"), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		decimals := -1
+		if value, ok := sf.options["decimals"]; ok {
+			n, err := strconv.ParseUint(value, 10, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid decimals %q", value)
+			}
+			decimals = int(n)
+		}
+		return strconv.FormatFloat(v.Float(), 'f', decimals, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// Unmarshal stores the values of rec in the struct pointed to by v. Fields
+// missing from the record are left unchanged. A blank value sets the struct
+// field to its zero value, unless the field has the omitempty option, in
+// which case the field is left unchanged.
+//
+// Example:
+//
+//	var customer Customer
+//	if err := dbf.Unmarshal(record, &customer); err != nil {
+//		log.Fatal(err)
+//	}
+func Unmarshal(rec *Record, v any) error {
+	rv, err := structValue(v, true)
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	for _, field := range structFields(rv.Type()) {
+		value, ok := rec.Data[field.name]
+		if !ok {
+			continue
+		}
+
+		fv := rv.Field(field.index)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			if !field.omitEmpty {
+				fv.SetZero()
+			}
+			continue
+		}
+
+		if err := parseValue(fv, value); err != nil {
+			return fmt.Errorf("unmarshal field %s: %w", field.name, err)
+		}
+	}
+
+	return nil
+}
+
+// parseValue parses a record value into a struct field.
+func parseValue(v reflect.Value, value string) error {
+	if _, ok := v.Interface().(time.Time); ok {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// NewWriterFromStruct creates a Writer whose fields are derived from the
+// struct type of prototype (a struct or a pointer to one), so that records
+// produced by Marshal can be written directly. The field definitions are
+// given in the dbf struct tags with the type, length and decimals options:
+//
+//	type Product struct {
+//		Name  string    `dbf:"NAME,type=C,length=20"`
+//		Price float64   `dbf:"PRICE,length=10,decimals=2"`
+//		Added time.Time `dbf:"ADDED"`
+//		InUse bool      `dbf:"INUSE"`
+//	}
+//
+// Without a type option the type follows the Go type: C for strings, N for
+// numbers, D for time.Time and L for bool. Character and numeric fields need
+// a length; dates and logicals have a fixed length.
+//
+// Example:
+//
+//	writer, err := dbf.NewWriterFromStruct(out, Product{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	rec, _ := dbf.Marshal(Product{Name: "Widget", Price: 9.99})
+//	err = writer.AppendRecord(rec)
+func NewWriterFromStruct(w io.Writer, prototype any, opts ...WriterOption) (*Writer, error) {
+	fields, err := fieldsFromStruct(prototype)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriter(w, fields, opts...)
+}
+
+// fieldsFromStruct derives field definitions from the dbf struct tags of
+// prototype.
+func fieldsFromStruct(prototype any) ([]Field, error) {
+	rv, err := structValue(prototype, false)
+	if err != nil {
+		return nil, fmt.Errorf("fields from struct: %w", err)
+	}
+
+	var fields []Field
+	for _, sf := range structFields(rv.Type()) {
+		field, err := sf.definition(rv.Field(sf.index).Type())
+		if err != nil {
+			return nil, fmt.Errorf("fields from struct: field %s: %w", sf.name, err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// definition returns the DBF field definition of a struct field of type t.
+func (sf structField) definition(t reflect.Type) (Field, error) {
+	field := Field{Name: sf.name, RawName: sf.name}
+
+	if typ, ok := sf.options["type"]; ok {
+		if len(typ) != 1 {
+			return field, fmt.Errorf("invalid type %q", typ)
+		}
+		field.Type = typ[0]
+	} else {
+		switch {
+		case t == reflect.TypeOf(time.Time{}):
+			field.Type = 'D'
+		case t.Kind() == reflect.String:
+			field.Type = 'C'
+		case t.Kind() == reflect.Bool:
+			field.Type = 'L'
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+			field.Type = 'N'
+		default:
+			return field, fmt.Errorf("unsupported type %s", t)
+		}
+	}
+
+	switch field.Type {
+	case 'D':
+		field.Length = 8
+	case 'L':
+		field.Length = 1
+	}
+
+	for key, dst := range map[string]*byte{"length": &field.Length, "decimals": &field.DecimalCount} {
+		value, ok := sf.options[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return field, fmt.Errorf("invalid %s %q", key, value)
+		}
+		*dst = byte(n)
+	}
+
+	if field.Length == 0 {
+		return field, errors.New("missing length")
+	}
+	return field, nil
+}
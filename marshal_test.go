@@ -0,0 +1,231 @@
+package dbf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testCustomer struct {
+	Name    string    `dbf:"NAME"`
+	Age     int       `dbf:"AGE,omitempty"`
+	Since   time.Time `dbf:"SINCE"`
+	Active  bool      `dbf:"ACTIVE,omitempty"`
+	Balance float64
+	Ratio   float32
+	Notes   string `dbf:"-"`
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	in := testCustomer{
+		Name:    "Alice",
+		Age:     42,
+		Since:   time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC),
+		Active:  true,
+		Balance: 12.5,
+		Ratio:   9.99,
+		Notes:   "ignored",
+	}
+
+	rec, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	expected := map[string]string{"NAME": "Alice", "AGE": "42", "SINCE": "20200517", "ACTIVE": "true", "BALANCE": "12.5", "RATIO": "9.99"}
+	if !(&Record{Data: expected}).Equal(rec) {
+		t.Fatalf("Expected %v, got %v", expected, rec.Data)
+	}
+
+	var out testCustomer
+	if err := Unmarshal(rec, &out); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	in.Notes = ""
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+
+	if err := Unmarshal(rec, out); err == nil {
+		t.Error("Expected error for a non-pointer destination")
+	}
+}
+
+func TestMarshalOmitEmpty(t *testing.T) {
+	rec, err := Marshal(&testCustomer{Name: "Bob"})
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+
+	// ACTIVE has omitempty and is written as a blank field; numbers are
+	// written as 0 with or without omitempty
+	if value, ok := rec.Data["ACTIVE"]; !ok || value != "" {
+		t.Errorf("Expected blank ACTIVE, got %q", value)
+	}
+	if rec.Data["AGE"] != "0" {
+		t.Errorf("Expected AGE 0, got %q", rec.Data["AGE"])
+	}
+	if rec.Data["BALANCE"] != "0" {
+		t.Errorf("Expected BALANCE 0, got %q", rec.Data["BALANCE"])
+	}
+
+	// round trip through a file: blank fields leave omitempty fields untouched
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, []Field{
+		NewCharField("NAME", 10),
+		NewNumericField("AGE", 3, 0),
+		NewNumericField("BALANCE", 8, 2),
+		NewLogicalField("ACTIVE"),
+	})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(rec); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(buf.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	read, _, err := reader.NextRecord()
+	if err != nil {
+		t.Fatalf("NextRecord() failed: %v", err)
+	}
+
+	out := testCustomer{Age: 7, Balance: 99, Active: true}
+	if err := Unmarshal(read, &out); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if !out.Active {
+		t.Error("Expected omitempty ACTIVE to stay true")
+	}
+	if out.Age != 0 {
+		t.Errorf("Expected AGE 0, got %d", out.Age)
+	}
+	if out.Balance != 0 {
+		t.Errorf("Expected BALANCE 0, got %v", out.Balance)
+	}
+	if out.Name != "Bob" {
+		t.Errorf("Expected 'Bob', got %q", out.Name)
+	}
+}
+
+type testProduct struct {
+	Name  string    `dbf:"NAME,type=C,length=20"`
+	Price float64   `dbf:"PRICE,length=10,decimals=2"`
+	Added time.Time `dbf:"ADDED"`
+	InUse bool      `dbf:"INUSE"`
+}
+
+func TestNewWriterFromStruct(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriterFromStruct(&buf, testProduct{})
+	if err != nil {
+		t.Fatalf("NewWriterFromStruct() failed: %v", err)
+	}
+
+	expected := []Field{
+		NewCharField("NAME", 20),
+		NewNumericField("PRICE", 10, 2),
+		NewDateField("ADDED"),
+		NewLogicalField("INUSE"),
+	}
+	if !SchemaEqual(writer.Fields(), expected) {
+		t.Fatalf("Expected fields %v, got %v", expected, writer.Fields())
+	}
+
+	in := testProduct{Name: "Widget", Price: 9.99, Added: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), InUse: true}
+	rec, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	if err := writer.AppendRecord(rec); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(buf.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	read, _, err := reader.NextRecord()
+	if err != nil {
+		t.Fatalf("NextRecord() failed: %v", err)
+	}
+	var out testProduct
+	if err := Unmarshal(read, &out); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
+
+func TestNewWriterFromStructErrors(t *testing.T) {
+	tests := map[string]any{
+		"missing length": struct {
+			Name string `dbf:"NAME"`
+		}{},
+		"unsupported type": struct {
+			Tags []string `dbf:"TAGS,length=10"`
+		}{},
+		"not a struct": 42,
+	}
+
+	for name, prototype := range tests {
+		if _, err := NewWriterFromStruct(&bytes.Buffer{}, prototype); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestMarshalDecimals(t *testing.T) {
+	type measurement struct {
+		Single float32 `dbf:"SINGLE,length=10,decimals=2"`
+		Double float64 `dbf:"DOUBLE,length=10,decimals=2"`
+		Zero   float64 `dbf:"ZERO,length=6,decimals=2,omitempty"`
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriterFromStruct(&buf, measurement{})
+	if err != nil {
+		t.Fatalf("NewWriterFromStruct() failed: %v", err)
+	}
+
+	rec, err := Marshal(measurement{Single: 9.99, Double: 10.0 / 3})
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	expected := map[string]string{"SINGLE": "9.99", "DOUBLE": "3.33", "ZERO": "0.00"}
+	if !(&Record{Data: expected}).Equal(rec) {
+		t.Fatalf("Expected %v, got %v", expected, rec.Data)
+	}
+
+	if err := writer.AppendRecord(rec); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(buf.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	read, _, err := reader.NextRecord()
+	if err != nil {
+		t.Fatalf("NextRecord() failed: %v", err)
+	}
+	var out measurement
+	if err := Unmarshal(read, &out); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if out.Single != 9.99 || out.Double != 3.33 || out.Zero != 0 {
+		t.Errorf("Expected {9.99 3.33 0}, got %+v", out)
+	}
+}
@@ -0,0 +1,395 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMemo is returned by memo methods when no memo file is attached to the reader.
+var ErrNoMemo = errors.New("dbf: no memo file attached")
+
+// WithMemoFile opens the memo file (.fpt or .dbt) at path and uses it to
+// resolve memo fields. The layout is chosen by the extension: dBASE for
+// ".dbt", FoxPro otherwise; HiPer-Six ".smt" files are rejected since their
+// blocks cannot be read. The file is closed by Reader.Close.
+func WithMemoFile(path string) Option {
+	return func(r *Reader) {
+		r.memoPath = path
+	}
+}
+
+// WithMemoBlockSize overrides the memo block size, for memo files whose
+// header does not record the size that was actually used. By default the size
+// is read from the .fpt header (bytes 6-7) or, for .dbt files, is 512 bytes
+// (dBASE III) or read from the header (dBASE IV).
+func WithMemoBlockSize(n int) Option {
+	return func(r *Reader) {
+		r.memoBlockSet = uint32(max(n, 0))
+	}
+}
+
+// WithNoAutoMemo stops NewFromFile from opening the table's memo file
+// automatically, for callers that handle memo files themselves.
+func WithNoAutoMemo() Option {
+	return func(r *Reader) {
+		r.noAutoMemo = true
+	}
+}
+
+// WithOnWarning registers a callback that is called with every warning as
+// it is recorded, in addition to collecting it in Warnings(). It is called
+// while the reader is being created, so header warnings are reported too.
+func WithOnWarning(fn func(string)) Option {
+	return func(r *Reader) {
+		r.onWarning = fn
+	}
+}
+
+// MemoFormat identifies the layout of a memo file.
+type MemoFormat int
+
+// Supported memo file formats.
+const (
+	MemoNone MemoFormat = iota // no memo file
+	MemoFPT                    // FoxPro .fpt
+	MemoDBT                    // dBASE .dbt
+	MemoSMT                    // HiPer-Six .smt, recognized but not readable
+)
+
+// String returns the file extension of the memo format, or "none".
+func (f MemoFormat) String() string {
+	switch f {
+	case MemoFPT:
+		return ".fpt"
+	case MemoDBT:
+		return ".dbt"
+	case MemoSMT:
+		return ".smt"
+	default:
+		return "none"
+	}
+}
+
+// memoFormatFromPath returns the memo format implied by the extension of
+// path; unknown extensions are read with the FoxPro layout.
+func memoFormatFromPath(path string) MemoFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".dbt":
+		return MemoDBT
+	case ".smt":
+		return MemoSMT
+	default:
+		return MemoFPT
+	}
+}
+
+// MemoFormat returns the format of the attached memo file, or, if none is
+// attached, the format the table's file type uses: .smt for HiPer-Six,
+// .dbt for dBASE and .fpt for FoxPro tables. MemoNone is returned for
+// tables without memo fields.
+//
+// HiPer-Six .smt memo files are only reported, never attached: their blocks
+// cannot be read, so NewFromFile falls back to a .fpt or .dbt file next to
+// the table and WithMemoFile rejects them. Clipper .dbv memo files are not
+// recognized at all, since their layout differs per driver.
+//
+// Example:
+//
+//	if reader.MemoFormat() == dbf.MemoSMT {
+//		fmt.Println("HiPer-Six memo file expected")
+//	}
+func (r *Reader) MemoFormat() MemoFormat {
+	if r.memo != nil {
+		return r.memoFormat
+	}
+	if !r.expectsMemoFile() {
+		return MemoNone
+	}
+	switch {
+	case r.fileType == HiPerSix:
+		return MemoSMT
+	case r.isDBaseType():
+		return MemoDBT
+	default:
+		return MemoFPT
+	}
+}
+
+// HasMemo reports whether a memo file is attached to the reader, either
+// with WithMemoFile or automatically by NewFromFile.
+func (r *Reader) HasMemo() bool {
+	return r.memo != nil
+}
+
+// expectsMemoFile reports whether the header indicates a memo file, either
+// through the table flags or through the file type.
+func (r *Reader) expectsMemoFile() bool {
+	if r.HasMemoFile() {
+		return true
+	}
+	switch r.fileType {
+	case FoxBASEPlusMemo, dBASEIVMemo, dBASEIVTFMemo, FoxPro2, HiPerSix, dBASE7Memo:
+		return true
+	}
+	return false
+}
+
+// isDBaseType reports whether the file type belongs to the dBASE family,
+// whose memo files use the .dbt layout.
+func (r *Reader) isDBaseType() bool {
+	switch r.fileType {
+	case FoxBASEPlusMemo, dBASEIVMemo, dBASEIVTFMemo, dBASEIVTF, dBASEIVSF, dBASE7, dBASE7Memo:
+		return true
+	}
+	return false
+}
+
+// memoFilePath returns the path of the memo file next to the table, or "".
+// The extension of the table's memo format is tried first; .smt files are
+// skipped since their blocks cannot be read.
+func (r *Reader) memoFilePath() string {
+	var extensions []string
+	switch r.MemoFormat() {
+	case MemoSMT:
+		extensions = []string{".fpt", ".dbt"}
+	case MemoDBT:
+		extensions = []string{".dbt", ".fpt"}
+	default:
+		extensions = []string{".fpt", ".dbt"}
+	}
+
+	path, _ := findSibling(r.path, extensions...)
+	return path
+}
+
+// openMemo opens the memo file at path and reads its header.
+func (r *Reader) openMemo(path string) error {
+	format := memoFormatFromPath(path)
+	if format == MemoSMT {
+		return fmt.Errorf("%s memo files are not supported", format)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, memoHeaderSize)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("read memo header: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.memoSize = info.Size()
+
+	r.memoFormat = format
+	switch r.memoFormat {
+	case MemoDBT:
+		// dBASE III uses 512-byte blocks; dBASE IV stores the size at offset 20
+		r.memoBlockSize = 512
+		if size := binary.LittleEndian.Uint16(header[20:22]); r.fileType != FoxBASEPlusMemo && size > 0 {
+			r.memoBlockSize = uint32(size)
+		}
+	default:
+		r.memoBlockSize = uint32(binary.BigEndian.Uint16(header[6:8]))
+	}
+	if r.memoBlockSize == 0 {
+		r.memoBlockSize = 512
+	}
+
+	if r.memoBlockSet > 0 {
+		r.memoBlockSize = r.memoBlockSet
+	}
+
+	r.memo = file
+	r.memoCloser = file
+	return nil
+}
+
+// ReadMemo returns the raw contents of the memo stored at the given block
+// number, as found in the value of a memo field.
+// Returns ErrNoMemo if no memo file is attached.
+func (r *Reader) ReadMemo(block uint32) ([]byte, error) {
+	if r.memo == nil {
+		return nil, ErrNoMemo
+	}
+	if block == 0 {
+		return nil, nil
+	}
+
+	offset := int64(block) * int64(r.memoBlockSize)
+
+	head := make([]byte, 8)
+	if _, err := r.memo.ReadAt(head, offset); err != nil {
+		return nil, fmt.Errorf("read memo block %d: %w", block, err)
+	}
+
+	if r.memoFormat != MemoDBT {
+		// FoxPro: 4-byte type and 4-byte length, big-endian
+		length := binary.BigEndian.Uint32(head[4:8])
+		return r.readMemoData(block, offset+8, length)
+	}
+
+	if bytes.Equal(head[0:4], []byte{0xFF, 0xFF, 0x08, 0x00}) {
+		// dBASE IV: length includes the 8-byte block header
+		length := binary.LittleEndian.Uint32(head[4:8])
+		if length < 8 {
+			return nil, fmt.Errorf("read memo block %d: invalid length %d", block, length)
+		}
+		return r.readMemoData(block, offset+8, length-8)
+	}
+
+	// dBASE III: text terminated by 0x1A, read block by block
+	var data []byte
+	chunk := make([]byte, r.memoBlockSize)
+	for {
+		n, err := r.memo.ReadAt(chunk, offset+int64(len(data)))
+		if i := bytes.IndexByte(chunk[:n], 0x1A); i >= 0 {
+			return append(data, chunk[:i]...), nil
+		}
+		data = append(data, chunk[:n]...)
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read memo block %d: %w", block, err)
+		}
+	}
+}
+
+// readMemoData reads length bytes of memo data at offset.
+// A length that exceeds the memo file is reported as an error instead of
+// being allocated, since it can only come from a corrupt block header.
+func (r *Reader) readMemoData(block uint32, offset int64, length uint32) ([]byte, error) {
+	if offset+int64(length) > r.memoSize {
+		return nil, fmt.Errorf("read memo block %d: length %d exceeds memo file size %d", block, length, r.memoSize)
+	}
+	data := make([]byte, length)
+	if _, err := r.memo.ReadAt(data, offset); err != nil {
+		return nil, fmt.Errorf("read memo block %d: %w", block, err)
+	}
+	return data, nil
+}
+
+// MemoText returns the contents of a memo (M) field of rec, decoded with the
+// reader's encoding. General (G) fields are returned undecoded.
+// An empty string is returned for records without a memo.
+// Returns ErrNoMemo if no memo file is attached and ErrUnknownField if the
+// field does not exist.
+//
+// Example:
+//
+//	reader, _ := dbf.NewFromFile("customers.dbf") // opens customers.fpt automatically
+//	for reader.Next() {
+//		record, _ := reader.Read()
+//		notes, err := reader.MemoText(record, "NOTES")
+//		...
+//	}
+func (r *Reader) MemoText(rec *Record, name string) (string, error) {
+	field, ok := r.field(name)
+	if !ok {
+		return "", fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+	if field.Type != 'M' && field.Type != 'G' {
+		return "", fmt.Errorf("field %s: not a memo field", name)
+	}
+
+	value := rec.Data[r.dataKey(field)]
+	if value == "" || value == r.nullString {
+		return "", nil
+	}
+
+	block, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("field %s: invalid memo block %q", name, value)
+	}
+
+	data, err := r.ReadMemo(uint32(block))
+	if err != nil {
+		return "", fmt.Errorf("field %s: %w", name, err)
+	}
+
+	if field.Type == 'G' {
+		return string(data), nil
+	}
+	decoded, err := r.fieldDecoder(field).Bytes(data)
+	if err != nil {
+		return string(data), nil // fallback to raw bytes
+	}
+	return string(decoded), nil
+}
+
+// resolveMemoText replaces the block numbers of the memo ('M') fields of
+// rec with their text.
+func (r *Reader) resolveMemoText(rec *Record) error {
+	for _, field := range r.fields {
+		if field.Type != 'M' {
+			continue
+		}
+		key := r.dataKey(field)
+		if value := rec.Data[key]; value == "" || value == r.nullString {
+			continue
+		}
+		text, err := r.MemoText(rec, field.Name)
+		if err != nil {
+			return err
+		}
+		rec.Data[key] = text
+	}
+	return nil
+}
+
+// ReadAllWithMemo reads all remaining records like ReadAll and replaces the
+// block number in every memo (M) and general (G) field with the contents of
+// the memo, so the records are complete without further lookups.
+// Returns ErrNoMemo if no memo file is attached.
+//
+// Example:
+//
+//	reader, _ := dbf.NewFromFile("customers.dbf")
+//	records, err := reader.ReadAllWithMemo()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(records[0].Data["NOTES"]) // memo text, not a block number
+func (r *Reader) ReadAllWithMemo() ([]*Record, error) {
+	if r.memo == nil {
+		return nil, fmt.Errorf("read all with memo: %w", ErrNoMemo)
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return records, err
+	}
+
+	for i, record := range records {
+		for _, field := range r.fields {
+			if field.Type != 'M' && field.Type != 'G' {
+				continue
+			}
+			key := r.dataKey(field)
+			if value := record.Data[key]; value == "" || value == r.nullString {
+				continue // no memo; keep the null value
+			}
+			text, err := r.MemoText(record, field.Name)
+			if err != nil {
+				return records, fmt.Errorf("record %d: %w", i, err)
+			}
+			record.Data[key] = text
+		}
+	}
+
+	return records, nil
+}
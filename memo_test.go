@@ -0,0 +1,429 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// createMemoTable writes a table with a memo field and its memo file next to it
+// and returns the path of the table
+func createMemoTable(t *testing.T, memoExt string, notes []string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "notes.dbf"))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	defer file.Close()
+
+	writer, err := NewWriter(file, []Field{
+		{Name: "ID", Type: 'N', Length: 3},
+		{Name: "NOTES", Type: 'M', Length: 10},
+	}, WithWriterEncoding(charmap.CodePage866), WithMemoOutput(filepath.Join(dir, "notes"+memoExt)))
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+
+	for i, text := range notes {
+		rec := &Record{Data: map[string]string{"ID": string(rune('1' + i)), "NOTES": text}}
+		if err := writer.AppendRecord(rec); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	return file.Name()
+}
+
+func TestMemoAutoOpen(t *testing.T) {
+	notes := []string{"Первая заметка", "", strings.Repeat("long text ", 100)}
+
+	for _, ext := range []string{".fpt", ".dbt"} {
+		t.Run(ext, func(t *testing.T) {
+			reader, err := NewFromFile(createMemoTable(t, ext, notes))
+			if err != nil {
+				t.Fatalf("NewFromFile() failed: %v", err)
+			}
+			defer reader.Close()
+
+			if !reader.HasMemo() {
+				t.Fatal("Expected memo file to be opened automatically")
+			}
+
+			records, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() failed: %v", err)
+			}
+
+			for i, want := range notes {
+				text, err := reader.MemoText(records[i], "NOTES")
+				if err != nil {
+					t.Fatalf("MemoText() failed: %v", err)
+				}
+				if text != want {
+					t.Errorf("Record %d: expected %q, got %q", i, want, text)
+				}
+			}
+		})
+	}
+}
+
+func TestWithNoAutoMemo(t *testing.T) {
+	reader, err := NewFromFile(createMemoTable(t, ".fpt", []string{"text"}), WithNoAutoMemo())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.HasMemo() {
+		t.Error("Expected no memo file with WithNoAutoMemo()")
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if _, err := reader.MemoText(records[0], "NOTES"); !errors.Is(err, ErrNoMemo) {
+		t.Errorf("Expected ErrNoMemo, got %v", err)
+	}
+}
+
+func TestWithMemoFile(t *testing.T) {
+	path := createMemoTable(t, ".fpt", []string{"explicit"})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(data, WithMemoFile(strings.TrimSuffix(path, ".dbf")+".fpt"))
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	defer reader.Close()
+
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+	text, err := reader.MemoText(record, "NOTES")
+	if err != nil {
+		t.Fatalf("MemoText() failed: %v", err)
+	}
+	if text != "explicit" {
+		t.Errorf("Expected 'explicit', got %q", text)
+	}
+
+	if _, err := NewFromBytes(data, WithMemoFile(filepath.Join(t.TempDir(), "missing.fpt"))); err == nil {
+		t.Error("Expected error for missing memo file, got nil")
+	}
+}
+
+func TestMemoUnreadableWarning(t *testing.T) {
+	path := createMemoTable(t, ".fpt", []string{"text"})
+	if err := os.WriteFile(strings.TrimSuffix(path, ".dbf")+".fpt", []byte("short"), 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	var warnings []string
+	reader, err := NewFromFile(path, WithOnWarning(func(msg string) {
+		warnings = append(warnings, msg)
+	}))
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.HasMemo() {
+		t.Error("Expected no memo file for an unreadable memo")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "cannot be read") {
+		t.Errorf("Expected a memo warning, got %v", warnings)
+	}
+	if len(reader.Warnings()) != 1 {
+		t.Errorf("Expected Warnings() to contain the memo warning, got %v", reader.Warnings())
+	}
+}
+
+func TestBinaryMemoBlockNumber(t *testing.T) {
+	writer, file := createTestWriter(t, []Field{{Name: "NOTES", Type: 'M', Length: 4}})
+	if err := writer.AppendRecord(&Record{Data: map[string]string{}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	// Visual FoxPro style: 4-byte little-endian block numbers, 0 for no memo
+	offset := 32 + 32 + 1
+	binary.LittleEndian.PutUint32(data[offset+1:], 300)
+	binary.LittleEndian.PutUint32(data[offset+6:], 0)
+
+	reader, err := NewFromBytes(data)
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if records[0].Data["NOTES"] != "300" {
+		t.Errorf("Expected block '300', got '%s'", records[0].Data["NOTES"])
+	}
+	if records[1].Data["NOTES"] != "" {
+		t.Errorf("Expected empty block, got '%s'", records[1].Data["NOTES"])
+	}
+}
+
+func TestMemoBlockSize(t *testing.T) {
+	notes := []string{"first", strings.Repeat("x", 150), "third"}
+	path := createMemoTable(t, ".fpt", notes)
+	memoPath := strings.TrimSuffix(path, ".dbf") + ".fpt"
+
+	// the writer uses 64-byte blocks, recorded in the header
+	reader, err := NewFromFile(path)
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if text, err := reader.MemoText(records[2], "NOTES"); err != nil || text != "third" {
+		t.Errorf("Expected 'third', got %q, %v", text, err)
+	}
+	reader.Close()
+
+	// clear the block size in the header; the option supplies it
+	memo, err := os.ReadFile(memoPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	binary.BigEndian.PutUint16(memo[6:8], 0)
+	if err := os.WriteFile(memoPath, memo, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	reader, err = NewFromFile(path, WithMemoBlockSize(64))
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err = reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	for i, want := range notes {
+		text, err := reader.MemoText(records[i], "NOTES")
+		if err != nil {
+			t.Fatalf("MemoText() failed: %v", err)
+		}
+		if text != want {
+			t.Errorf("Record %d: expected %q, got %q", i, want, text)
+		}
+	}
+}
+
+func TestMemoCorruptLength(t *testing.T) {
+	path := createMemoTable(t, ".fpt", []string{"text"})
+	memoPath := strings.TrimSuffix(path, ".dbf") + ".fpt"
+
+	reader, err := NewFromFile(path)
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+	record, _, err := reader.NextRecord()
+	if err != nil {
+		t.Fatalf("NextRecord() failed: %v", err)
+	}
+	block, _ := strconv.Atoi(record.Data["NOTES"])
+
+	// a corrupt length must not be allocated
+	memo, err := os.ReadFile(memoPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	offset := block * int(reader.memoBlockSize)
+	binary.BigEndian.PutUint32(memo[offset+4:offset+8], 0xFFFFFFF0)
+	if err := os.WriteFile(memoPath, memo, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	if _, err := reader.MemoText(record, "NOTES"); err == nil || !strings.Contains(err.Error(), "exceeds memo file size") {
+		t.Errorf("Expected a length error, got %v", err)
+	}
+}
+
+func TestReadAllWithMemo(t *testing.T) {
+	notes := []string{"Первая заметка", "", "third"}
+
+	reader, err := NewFromFile(createMemoTable(t, ".fpt", notes))
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAllWithMemo()
+	if err != nil {
+		t.Fatalf("ReadAllWithMemo() failed: %v", err)
+	}
+	if len(records) != len(notes) {
+		t.Fatalf("Expected %d records, got %d", len(notes), len(records))
+	}
+	for i, want := range notes {
+		if got := records[i].Data["NOTES"]; got != want {
+			t.Errorf("Record %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestReadAllWithMemoEmptyBlock(t *testing.T) {
+	path := createMemoTable(t, ".fpt", []string{"text"})
+	memoPath := strings.TrimSuffix(path, ".dbf") + ".fpt"
+
+	// shrink the stored memo to zero length
+	memo, err := os.ReadFile(memoPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	blockSize := int(binary.BigEndian.Uint16(memo[6:8]))
+	offset := (memoHeaderSize + blockSize - 1) / blockSize * blockSize // first block after the header
+	binary.BigEndian.PutUint32(memo[offset+4:offset+8], 0)
+	if err := os.WriteFile(memoPath, memo, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(path)
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAllWithMemo()
+	if err != nil {
+		t.Fatalf("ReadAllWithMemo() failed: %v", err)
+	}
+	if got := records[0].Data["NOTES"]; got != "" {
+		t.Errorf("Expected empty memo text instead of the block number, got %q", got)
+	}
+}
+
+func TestReadAllWithMemoMissingFile(t *testing.T) {
+	reader, err := NewFromFile(createMemoTable(t, ".fpt", []string{"text"}), WithNoAutoMemo())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if _, err := reader.ReadAllWithMemo(); !errors.Is(err, ErrNoMemo) {
+		t.Errorf("Expected ErrNoMemo, got %v", err)
+	}
+}
+
+func TestMemoFormat(t *testing.T) {
+	for ext, want := range map[string]MemoFormat{".fpt": MemoFPT, ".dbt": MemoDBT} {
+		t.Run(ext, func(t *testing.T) {
+			path := createMemoTable(t, ext, []string{"text"})
+
+			reader, err := NewFromFile(path, WithNoAutoMemo())
+			if err != nil {
+				t.Fatalf("NewFromFile() failed: %v", err)
+			}
+			defer reader.Close()
+			if got := reader.MemoFormat(); got != want {
+				t.Errorf("Expected %s from the file type, got %s", want, got)
+			}
+		})
+	}
+
+	reader, err := New(bytes.NewReader(createMinimalDBF()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got := reader.MemoFormat(); got != MemoNone {
+		t.Errorf("Expected %s for a table without memo, got %s", MemoNone, got)
+	}
+}
+
+func TestMemoFormatHiPerSix(t *testing.T) {
+	path := createMemoTable(t, ".fpt", []string{"text"})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	data[0] = byte(HiPerSix)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	// the .smt file cannot be read, so the .fpt file is attached instead
+	header := make([]byte, memoHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:4], 1)
+	binary.LittleEndian.PutUint16(header[4:6], 32)
+	smtPath := strings.TrimSuffix(path, ".dbf") + ".smt"
+	if err := os.WriteFile(smtPath, header, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(path)
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if got := reader.MemoFormat(); got != MemoFPT {
+		t.Fatalf("Expected %s, got %s", MemoFPT, got)
+	}
+	records, err := reader.ReadAllWithMemo()
+	if err != nil {
+		t.Fatalf("ReadAllWithMemo() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NOTES"] != "text" {
+		t.Errorf("Expected memo text %q, got %v", "text", records)
+	}
+
+	// with only the .smt file the format is reported but nothing is attached
+	if err := os.Remove(strings.TrimSuffix(path, ".dbf") + ".fpt"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	reader, err = NewFromFile(path)
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if got := reader.MemoFormat(); got != MemoSMT {
+		t.Errorf("Expected %s, got %s", MemoSMT, got)
+	}
+	if reader.HasMemo() {
+		t.Error("Expected the .smt file not to be attached")
+	}
+	if _, err := reader.ReadAllWithMemo(); !errors.Is(err, ErrNoMemo) {
+		t.Errorf("Expected ErrNoMemo, got %v", err)
+	}
+
+	if _, err := NewFromFile(path, WithMemoFile(smtPath)); err == nil {
+		t.Error("Expected an error attaching an .smt memo file")
+	}
+}
@@ -0,0 +1,157 @@
+package dbf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OpenMulti opens several DBF files with identical schemas and returns a
+// Reader that iterates over the records of all files in the given order,
+// as if they were a single table.
+//
+// Header information (file type, encoding, fields) is taken from the first
+// file and RecordsCount() returns the total across all files. An error naming
+// the offending file is returned if any schema differs from the first one.
+//
+// Only sequential reading (Next/Read/ReadAll) is supported; random access
+// methods return ErrNotSeekable.
+//
+// Example:
+//
+//	reader, err := dbf.OpenMulti([]string{"2024-01.dbf", "2024-02.dbf"}, dbf.WithCP866())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reader.Close()
+func OpenMulti(paths []string, opts ...Option) (*Reader, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("open multi: no files given")
+	}
+
+	segments := make([]*Reader, 0, len(paths))
+	closeAll := func() {
+		for _, segment := range segments {
+			_ = segment.Close()
+		}
+	}
+
+	for _, path := range paths {
+		segment, err := NewFromFile(path, opts...)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		segments = append(segments, segment)
+
+		if !SchemaEqual(segments[0].fields, segment.fields) {
+			closeAll()
+			return nil, fmt.Errorf("schema of %s does not match %s", path, paths[0])
+		}
+	}
+
+	// settings come from the options, as for every segment; the header and
+	// the state New derives from it come from the first file
+	first := segments[0]
+	reader := newReader(opts...)
+	reader.fileType = first.fileType
+	reader.lastUpdate = first.lastUpdate
+	reader.headerBytesNumber = first.headerBytesNumber
+	reader.recordBytesNumber = first.recordBytesNumber
+	reader.fieldsCount = first.fieldsCount
+	reader.ldid = first.ldid
+	reader.tableFlags = first.tableFlags
+	reader.fields = first.fields
+	reader.aliasedFields = first.aliasedFields
+	reader.decoder = first.decoder
+	reader.fieldDecoders = first.fieldDecoders
+	reader.segments = segments
+
+	for _, segment := range segments {
+		reader.recordsCount += segment.recordsCount
+		for _, warning := range segment.warnings {
+			reader.warnings = append(reader.warnings, segment.path+": "+warning)
+		}
+	}
+
+	return reader, nil
+}
+
+// nextSegment advances to the next record, moving on to the next file
+// when the current one is exhausted.
+func (r *Reader) nextSegment() bool {
+	for r.segment < len(r.segments) {
+		segment := r.segments[r.segment]
+		if segment.Next() {
+			r.currentRecord++
+			return true
+		}
+		if err := segment.Err(); err != nil {
+			r.err = fmt.Errorf("%s: %w", segment.path, err)
+			return false
+		}
+		r.segment++
+	}
+	return false
+}
+
+// readSegment reads the current record from the current file.
+func (r *Reader) readSegment(withRaw bool) (*Record, error) {
+	if r.segment >= len(r.segments) {
+		return nil, fmt.Errorf("read record: %w", ErrOutOfRange)
+	}
+
+	segment := r.segments[r.segment]
+	record, err := segment.read(withRaw)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", segment.path, err)
+		if record != nil && segment.partialRecords {
+			return record, err // iteration continues, as in a single file
+		}
+		r.err = err
+		return nil, r.err
+	}
+	return record, nil
+}
+
+// MultiReader reads a logical table that is split across several files with
+// identical schemas, such as TABLE_A.DBF and TABLE_B.DBF written by Clipper
+// or FoxPro applications.
+//
+// It embeds a *Reader created by OpenMulti, so the usual Next/Read/ReadAll/Err
+// API works unchanged and moves transparently from one file to the next.
+// RecordsCount returns the total across all files.
+type MultiReader struct {
+	*Reader
+}
+
+// NewMultiReader opens all files and validates that their schemas match.
+// See OpenMulti for details.
+//
+// Example:
+//
+//	reader, err := dbf.NewMultiReader([]string{"TABLE_A.DBF", "TABLE_B.DBF"}, dbf.WithCP866())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reader.Close()
+//
+//	for reader.Next() {
+//		record, err := reader.Read()
+//		// ...
+//	}
+func NewMultiReader(paths []string, opts ...Option) (*MultiReader, error) {
+	reader, err := OpenMulti(paths, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiReader{Reader: reader}, nil
+}
+
+// Files returns the paths of the files in reading order.
+func (m *MultiReader) Files() []string {
+	paths := make([]string, 0, len(m.segments))
+	for _, segment := range m.segments {
+		paths = append(paths, segment.path)
+	}
+	return paths
+}
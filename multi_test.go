@@ -0,0 +1,246 @@
+package dbf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestFile writes data to a file in a temporary directory and returns its path
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	return path
+}
+
+func TestOpenMulti(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.RecordsCount() != 4 {
+		t.Errorf("Expected 4 records, got %d", reader.RecordsCount())
+	}
+	if reader.FieldsCount() != 1 {
+		t.Errorf("Expected 1 field, got %d", reader.FieldsCount())
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected 4 records, got %d", len(records))
+	}
+
+	names := []string{"John Doe", "Jane Smith", "John Doe", "Jane Smith"}
+	for i, record := range records {
+		if record.Data["NAME"] != names[i] {
+			t.Errorf("Record %d: expected '%s', got '%s'", i, names[i], record.Data["NAME"])
+		}
+	}
+}
+
+func TestOpenMultiSchemaMismatch(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createDBFWithMultipleFields())
+
+	_, err := OpenMulti([]string{first, second}, WithCP866())
+	if err == nil {
+		t.Fatal("Expected error for mismatched schemas, got nil")
+	}
+	if !strings.Contains(err.Error(), second) {
+		t.Errorf("Error should name the offending file, got: %v", err)
+	}
+}
+
+func TestOpenMultiNoFiles(t *testing.T) {
+	if _, err := OpenMulti(nil); err == nil {
+		t.Error("Expected error for empty path list, got nil")
+	}
+}
+
+func TestNewMultiReader(t *testing.T) {
+	first := writeTestFile(t, "TABLE_A.DBF", createMinimalDBF())
+	second := writeTestFile(t, "TABLE_B.DBF", createMinimalDBF())
+
+	reader, err := NewMultiReader([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("NewMultiReader() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.RecordsCount() != 4 {
+		t.Errorf("Expected 4 records, got %d", reader.RecordsCount())
+	}
+	if files := reader.Files(); len(files) != 2 || files[0] != first || files[1] != second {
+		t.Errorf("Unexpected files: %v", files)
+	}
+
+	count := 0
+	for reader.Next() {
+		if _, err := reader.Read(); err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+		count++
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("Err() returned error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected to read 4 records, got %d", count)
+	}
+}
+
+func TestNewMultiReaderSchemaMismatch(t *testing.T) {
+	first := writeTestFile(t, "TABLE_A.DBF", createMinimalDBF())
+	second := writeTestFile(t, "TABLE_B.DBF", createDBFWithMultipleFields())
+
+	if _, err := NewMultiReader([]string{first, second}, WithCP866()); err == nil {
+		t.Error("Expected error for mismatched schemas, got nil")
+	}
+}
+
+func TestOpenMultiSkipRecords(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if err := reader.SkipRecords(3); err != nil {
+		t.Fatalf("SkipRecords() failed: %v", err)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NAME"] != "Jane Smith" {
+		t.Errorf("Expected only the last record after skip, got %v", records)
+	}
+}
+
+func TestOpenMultiPartialRecords(t *testing.T) {
+	truncated := createMinimalDBF()
+	truncated = truncated[:len(truncated)-6] // last record ends after "Jane"
+
+	first := writeTestFile(t, "a.dbf", truncated)
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866(), WithPartialRecords())
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+
+	var names []string
+	var fieldErrors int
+	for reader.Next() {
+		record, err := reader.Read()
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			fieldErrors++
+			if record == nil {
+				t.Fatal("Expected the partial record with the error")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+		names = append(names, record.Data["NAME"])
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if fieldErrors != 1 {
+		t.Errorf("Expected 1 partial record, got %d", fieldErrors)
+	}
+	expected := []string{"John Doe", "John Doe", "Jane Smith"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v after the truncated record, got %v", expected, names)
+	}
+}
+
+func TestOpenMultiReadAllContext(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := NewMultiReader([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("NewMultiReader() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAllContext(context.Background())
+	if err != nil {
+		t.Fatalf("ReadAllContext() failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("Expected 4 records, got %d", len(records))
+	}
+}
+
+func TestOpenMultiDatePivot(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+	if _, err := reader.ParseDate("990115"); err == nil {
+		t.Error("Expected error for a two-digit year without WithDateCentury")
+	}
+
+	reader, err = OpenMulti([]string{first, second}, WithCP866(), WithDateCentury(50))
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+	if d, err := reader.ParseDate("990115"); err != nil || d.Year() != 1999 {
+		t.Errorf("Expected 1999-01-15, got %v, %v", d, err)
+	}
+}
+
+func TestOpenMultiNullString(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{{"12.50", "20240115"}, {"", ""}})
+	first := writeTestFile(t, "a.dbf", data)
+	second := writeTestFile(t, "b.dbf", data)
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866(), WithNullString("NULL"), WithTrimMode(TrimNone))
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.nullString != "NULL" || reader.trimMode != TrimNone {
+		t.Errorf("Expected the options on the combined reader, got %q and %v", reader.nullString, reader.trimMode)
+	}
+
+	column, err := reader.Column("AMOUNT")
+	if err != nil {
+		t.Fatalf("Column() failed: %v", err)
+	}
+	if len(column) != 4 || column[0] != 12.5 || column[1] != nil || column[3] != nil {
+		t.Errorf("Expected [12.5 nil 12.5 nil], got %v", column)
+	}
+}
@@ -0,0 +1,175 @@
+package dbf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrSchemaMismatch is returned when records are copied between tables
+// whose schemas are not compatible.
+var ErrSchemaMismatch = errors.New("dbf: schema mismatch")
+
+// Schema describes the field layout of a DBF table.
+// It can be compared with other schemas and persisted as JSON.
+type Schema struct {
+	fields []Field
+}
+
+// NewSchema creates a Schema from a list of field definitions.
+func NewSchema(fields []Field) Schema {
+	return Schema{fields: slices.Clone(fields)}
+}
+
+// Schema returns the schema of the DBF table.
+func (r *Reader) Schema() Schema {
+	return NewSchema(r.fields)
+}
+
+// Schema returns the schema of the DBF file being written.
+func (w *Writer) Schema() Schema {
+	return NewSchema(w.fields)
+}
+
+// Fields returns the field definitions of the schema.
+func (s Schema) Fields() []Field {
+	return slices.Clone(s.fields)
+}
+
+// Compatible reports whether both schemas have the same field names, types,
+// lengths and decimal counts in the same order.
+func (s Schema) Compatible(other Schema) bool {
+	return SchemaEqual(s.fields, other.fields)
+}
+
+// Subset reports whether every field of other exists in s with the same
+// type, length and decimal count. Field order is not significant.
+func (s Schema) Subset(other Schema) bool {
+	for _, field := range other.fields {
+		i := slices.IndexFunc(s.fields, func(f Field) bool { return f.Name == field.Name })
+		if i < 0 || !SchemaEqual(s.fields[i:i+1], []Field{field}) {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaField is the JSON representation of a field definition.
+type schemaField struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Length   int    `json:"length"`
+	Decimals int    `json:"decimals"`
+}
+
+// JSON serializes the schema as an array of field objects:
+//
+//	[{"name":"NAME","type":"C","length":10,"decimals":0}]
+func (s Schema) JSON() ([]byte, error) {
+	fields := make([]schemaField, 0, len(s.fields))
+	for _, field := range s.fields {
+		fields = append(fields, schemaField{
+			Name:     field.Name,
+			Type:     string(field.Type),
+			Length:   int(field.Length),
+			Decimals: int(field.DecimalCount),
+		})
+	}
+	return json.Marshal(fields)
+}
+
+// FromJSON parses a schema previously produced by JSON.
+// The receiver is not modified; it only serves as a namespace:
+//
+//	schema, err := dbf.Schema{}.FromJSON(data)
+func (Schema) FromJSON(data []byte) (Schema, error) {
+	var fields []schemaField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Schema{}, fmt.Errorf("parse schema: %w", err)
+	}
+
+	schema := Schema{fields: make([]Field, 0, len(fields))}
+	for i, field := range fields {
+		if field.Name == "" {
+			return Schema{}, fmt.Errorf("field %d: missing name", i)
+		}
+		if len(field.Type) != 1 {
+			return Schema{}, fmt.Errorf("field %s: invalid type %q", field.Name, field.Type)
+		}
+		if field.Length < 0 || field.Length > 255 {
+			return Schema{}, fmt.Errorf("field %s: invalid length %d", field.Name, field.Length)
+		}
+		if field.Decimals < 0 || field.Decimals > 255 {
+			return Schema{}, fmt.Errorf("field %s: invalid decimal count %d", field.Name, field.Decimals)
+		}
+
+		schema.fields = append(schema.fields, Field{
+			Name:         field.Name,
+			RawName:      field.Name,
+			Type:         field.Type[0],
+			Length:       byte(field.Length),
+			DecimalCount: byte(field.Decimals),
+		})
+	}
+
+	return schema, nil
+}
+
+// String returns the schema as an aligned table of fields.
+func (s Schema) String() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tLENGTH\tDECIMALS")
+	for _, field := range s.fields {
+		fmt.Fprintf(w, "%s\t%c\t%d\t%d\n", field.Name, field.Type, field.Length, field.DecimalCount)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+// SchemaDiff describes the differences between two schemas.
+type SchemaDiff struct {
+	Added   []Field    // fields only present in the other schema
+	Removed []Field    // fields only present in the receiver
+	Changed [][2]Field // fields present in both with different definitions (receiver, other)
+}
+
+// Empty reports whether the schemas have the same fields.
+// Field order is not taken into account; use Compatible for that.
+func (d SchemaDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the schema with other by field name, e.g. to validate an
+// incoming file against a schema stored with FromJSON.
+//
+// Example:
+//
+//	expected, _ := dbf.Schema{}.FromJSON(stored)
+//	if diff := expected.Diff(reader.Schema()); !diff.Empty() {
+//		log.Printf("schema changed: %+v", diff)
+//	}
+func (s Schema) Diff(other Schema) SchemaDiff {
+	var diff SchemaDiff
+
+	for _, field := range s.fields {
+		i := slices.IndexFunc(other.fields, func(f Field) bool { return f.Name == field.Name })
+		switch {
+		case i < 0:
+			diff.Removed = append(diff.Removed, field)
+		case !SchemaEqual([]Field{field}, other.fields[i:i+1]):
+			diff.Changed = append(diff.Changed, [2]Field{field, other.fields[i]})
+		}
+	}
+
+	for _, field := range other.fields {
+		if !slices.ContainsFunc(s.fields, func(f Field) bool { return f.Name == field.Name }) {
+			diff.Added = append(diff.Added, field)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,157 @@
+package dbf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReaderSchema(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	schema := dbf.Schema()
+	if len(schema.Fields()) != 3 {
+		t.Fatalf("Expected 3 fields, got %d", len(schema.Fields()))
+	}
+
+	// the schema is a copy
+	schema.Fields()[0].Name = "CHANGED"
+	if dbf.Fields()[0].Name != "NAME" {
+		t.Error("Modifying schema fields should not affect the reader")
+	}
+}
+
+func TestSchemaCompatible(t *testing.T) {
+	a := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 10}, {Name: "AGE", Type: 'N', Length: 3}})
+	b := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 10}, {Name: "AGE", Type: 'N', Length: 3}})
+	c := NewSchema([]Field{{Name: "AGE", Type: 'N', Length: 3}, {Name: "NAME", Type: 'C', Length: 10}})
+	d := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 20}, {Name: "AGE", Type: 'N', Length: 3}})
+
+	if !a.Compatible(b) {
+		t.Error("Identical schemas should be compatible")
+	}
+	if a.Compatible(c) {
+		t.Error("Schemas with different field order should not be compatible")
+	}
+	if a.Compatible(d) {
+		t.Error("Schemas with different field lengths should not be compatible")
+	}
+}
+
+func TestSchemaSubset(t *testing.T) {
+	full := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 10}, {Name: "AGE", Type: 'N', Length: 3}})
+
+	if !full.Subset(NewSchema([]Field{{Name: "AGE", Type: 'N', Length: 3}})) {
+		t.Error("Expected AGE to be a subset")
+	}
+	if full.Subset(NewSchema([]Field{{Name: "AGE", Type: 'C', Length: 3}})) {
+		t.Error("Field with different type should not be a subset")
+	}
+	if full.Subset(NewSchema([]Field{{Name: "CITY", Type: 'C', Length: 10}})) {
+		t.Error("Missing field should not be a subset")
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	schema := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 10}, {Name: "PRICE", Type: 'N', Length: 8, DecimalCount: 2}})
+
+	data, err := schema.JSON()
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+
+	expected := `[{"name":"NAME","type":"C","length":10,"decimals":0},{"name":"PRICE","type":"N","length":8,"decimals":2}]`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	parsed, err := Schema{}.FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON() failed: %v", err)
+	}
+	if !parsed.Compatible(schema) {
+		t.Errorf("Round-tripped schema is not compatible: %v", parsed.Fields())
+	}
+}
+
+func TestSchemaFromJSONInvalid(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`[{"name":"","type":"C","length":10}]`,
+		`[{"name":"NAME","type":"CC","length":10}]`,
+		`[{"name":"NAME","type":"C","length":300}]`,
+	}
+
+	for _, data := range tests {
+		if _, err := (Schema{}).FromJSON([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s, got nil", data)
+		}
+	}
+}
+
+func TestSchemaString(t *testing.T) {
+	schema := NewSchema([]Field{{Name: "NAME", Type: 'C', Length: 10}})
+
+	str := schema.String()
+	if !strings.HasPrefix(str, "NAME  TYPE  LENGTH  DECIMALS\n") {
+		t.Errorf("Unexpected header: %q", str)
+	}
+	if !strings.Contains(str, "NAME  C     10      0") {
+		t.Errorf("Unexpected field line: %q", str)
+	}
+}
+
+func TestSchemaJSONRoundTripFromReader(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createDBFWithMultipleFields()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	stored, err := dbf.Schema().JSON()
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+
+	expected, err := Schema{}.FromJSON(stored)
+	if err != nil {
+		t.Fatalf("FromJSON() failed: %v", err)
+	}
+
+	if !expected.Compatible(dbf.Schema()) {
+		t.Error("Stored schema should be compatible with the source file")
+	}
+	if diff := expected.Diff(dbf.Schema()); !diff.Empty() {
+		t.Errorf("Expected empty diff, got %+v", diff)
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	old := NewSchema([]Field{
+		{Name: "NAME", Type: 'C', Length: 10},
+		{Name: "AGE", Type: 'N', Length: 3},
+		{Name: "CITY", Type: 'C', Length: 20},
+	})
+	updated := NewSchema([]Field{
+		{Name: "NAME", Type: 'C', Length: 30},
+		{Name: "AGE", Type: 'N', Length: 3},
+		{Name: "EMAIL", Type: 'C', Length: 40},
+	})
+
+	diff := old.Diff(updated)
+	if diff.Empty() {
+		t.Fatal("Expected differences")
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != "EMAIL" {
+		t.Errorf("Expected EMAIL to be added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "CITY" {
+		t.Errorf("Expected CITY to be removed, got %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0][0].Length != 10 || diff.Changed[0][1].Length != 30 {
+		t.Errorf("Expected NAME length change 10 -> 30, got %+v", diff.Changed)
+	}
+}
@@ -0,0 +1,75 @@
+package dbf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SiblingPaths holds the paths of the companion files of a DBF table.
+// When a file does not exist, its path is the conventional name (FoxPro
+// .fpt and .cdx, in the letter case of the table's extension).
+type SiblingPaths struct {
+	Memo     string // memo file (.fpt or .dbt)
+	HasMemo  bool
+	Index    string // production index (.cdx or .mdx)
+	HasIndex bool
+}
+
+// Siblings returns the paths of the memo and production index files that
+// belong to the table at dbfPath. File names are matched case-insensitively,
+// so DATA.DBF finds data.fpt on case-sensitive filesystems. The FoxPro
+// extensions are preferred when both variants exist.
+//
+// Example:
+//
+//	siblings := dbf.Siblings("data/CUSTOMERS.DBF")
+//	if siblings.HasMemo {
+//		fmt.Println("memo:", siblings.Memo) // data/CUSTOMERS.FPT
+//	}
+func Siblings(dbfPath string) SiblingPaths {
+	var siblings SiblingPaths
+
+	siblings.Memo, siblings.HasMemo = findSibling(dbfPath, ".fpt", ".dbt")
+	siblings.Index, siblings.HasIndex = findSibling(dbfPath, ".cdx", ".mdx")
+
+	if !siblings.HasMemo {
+		siblings.Memo = siblingPath(dbfPath, ".fpt")
+	}
+	if !siblings.HasIndex {
+		siblings.Index = siblingPath(dbfPath, ".cdx")
+	}
+
+	return siblings
+}
+
+// findSibling returns the first existing file next to dbfPath with the same
+// base name and one of the given extensions, in order, ignoring letter case.
+func findSibling(dbfPath string, extensions ...string) (string, bool) {
+	dir := filepath.Dir(dbfPath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+
+	base := strings.TrimSuffix(filepath.Base(dbfPath), filepath.Ext(dbfPath))
+	for _, ext := range extensions {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(entry.Name(), base+ext) {
+				return filepath.Join(dir, entry.Name()), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// siblingPath returns the conventional path of a companion file, using upper
+// case extensions for tables with an upper case extension.
+func siblingPath(dbfPath, ext string) string {
+	dbfExt := filepath.Ext(dbfPath)
+	if dbfExt != "" && dbfExt == strings.ToUpper(dbfExt) {
+		ext = strings.ToUpper(ext)
+	}
+	return strings.TrimSuffix(dbfPath, dbfExt) + ext
+}
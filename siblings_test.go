@@ -0,0 +1,37 @@
+package dbf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSiblings(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"CUSTOMERS.DBF", "customers.fpt", "CUSTOMERS.CDX", "orders.dbf", "orders.dbt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+	}
+
+	siblings := Siblings(filepath.Join(dir, "CUSTOMERS.DBF"))
+	if !siblings.HasMemo || siblings.Memo != filepath.Join(dir, "customers.fpt") {
+		t.Errorf("Expected memo customers.fpt, got %+v", siblings)
+	}
+	if !siblings.HasIndex || siblings.Index != filepath.Join(dir, "CUSTOMERS.CDX") {
+		t.Errorf("Expected index CUSTOMERS.CDX, got %+v", siblings)
+	}
+
+	siblings = Siblings(filepath.Join(dir, "orders.dbf"))
+	if !siblings.HasMemo || siblings.Memo != filepath.Join(dir, "orders.dbt") {
+		t.Errorf("Expected memo orders.dbt, got %+v", siblings)
+	}
+	if siblings.HasIndex || siblings.Index != filepath.Join(dir, "orders.cdx") {
+		t.Errorf("Expected missing index orders.cdx, got %+v", siblings)
+	}
+
+	siblings = Siblings(filepath.Join(dir, "MISSING.DBF"))
+	if siblings.HasMemo || siblings.Memo != filepath.Join(dir, "MISSING.FPT") {
+		t.Errorf("Expected missing memo MISSING.FPT, got %+v", siblings)
+	}
+}
@@ -0,0 +1,206 @@
+package dbf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FieldStats contains summary statistics of a numeric field.
+type FieldStats struct {
+	Min, Max, Sum    float64
+	Count, NullCount uint32 // non-blank and blank values
+	Mean             float64
+}
+
+// DateStats contains summary statistics of a date field.
+type DateStats struct {
+	Min, Max         time.Time
+	Count, NullCount uint32 // non-blank and blank values
+}
+
+// FieldStats computes minimum, maximum, sum and mean of a numeric (N, F)
+// field in a single pass over all records. Blank values and values lost to
+// numeric overflow (asterisks) are counted as nulls.
+//
+// The reader is rewound before scanning and is left positioned after the last
+// record, so the source must implement io.ReaderAt.
+// Returns ErrUnknownField if the field does not exist.
+func (r *Reader) FieldStats(name string) (FieldStats, error) {
+	var stats FieldStats
+
+	field, ok := r.field(name)
+	if !ok {
+		return stats, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+	if field.Type != 'N' && field.Type != 'F' {
+		return stats, fmt.Errorf("field %s: statistics not supported for %s fields", name, field.TypeString())
+	}
+
+	err := r.scanField(field, func(index uint32, value string) error {
+		v, ok, err := r.ParseNumeric(value)
+		if !ok && (err == nil || errors.Is(err, ErrNumericOverflow)) {
+			stats.NullCount++
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("record %d: parse %s: %w", index, name, err)
+		}
+
+		if stats.Count == 0 || v < stats.Min {
+			stats.Min = v
+		}
+		if stats.Count == 0 || v > stats.Max {
+			stats.Max = v
+		}
+		stats.Sum += v
+		stats.Count++
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	if stats.Count > 0 {
+		stats.Mean = stats.Sum / float64(stats.Count)
+	}
+	return stats, nil
+}
+
+// DateStats computes the earliest and latest value of a date (D) field in a
+// single pass over all records. Blank values are counted as nulls.
+//
+// The reader is rewound before scanning and is left positioned after the last
+// record, so the source must implement io.ReaderAt.
+// Returns ErrUnknownField if the field does not exist.
+func (r *Reader) DateStats(name string) (DateStats, error) {
+	var stats DateStats
+
+	field, ok := r.field(name)
+	if !ok {
+		return stats, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+	if field.Type != 'D' {
+		return stats, fmt.Errorf("field %s: date statistics not supported for %s fields", name, field.TypeString())
+	}
+
+	err := r.scanField(field, func(index uint32, value string) error {
+		if value == "" || value == r.nullString {
+			stats.NullCount++
+			return nil
+		}
+
+		v, err := r.ParseDate(value)
+		if err != nil {
+			return fmt.Errorf("record %d: %s: %w", index, name, err)
+		}
+
+		if stats.Count == 0 || v.Before(stats.Min) {
+			stats.Min = v
+		}
+		if stats.Count == 0 || v.After(stats.Max) {
+			stats.Max = v
+		}
+		stats.Count++
+		return nil
+	})
+
+	return stats, err
+}
+
+// GroupBy reads all remaining records and groups them by the value of the
+// named field. The map keys are the field values with surrounding spaces
+// removed. Deleted records are included unless the reader was created with
+// WithSkipDeleted.
+//
+// All records are loaded into memory; for large files, iterate with
+// Next()/Read() instead.
+// Returns ErrUnknownField if the field does not exist.
+//
+// Example:
+//
+//	groups, err := reader.GroupBy("REGION")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for region, records := range groups {
+//		fmt.Println(region, len(records))
+//	}
+func (r *Reader) GroupBy(name string) (map[string][]*Record, error) {
+	field, ok := r.field(name)
+	if !ok {
+		return nil, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]*Record)
+	for _, record := range records {
+		key := strings.TrimSpace(record.Data[r.dataKey(field)])
+		groups[key] = append(groups[key], record)
+	}
+	return groups, nil
+}
+
+// Distinct reads all remaining records and returns the unique values of the
+// named field in sorted order. Deleted records are included unless the
+// reader was created with WithSkipDeleted. Only the unique values are kept
+// in memory, not the records.
+// Returns ErrUnknownField if the field does not exist.
+//
+// Example:
+//
+//	regions, err := reader.Distinct("REGION")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (r *Reader) Distinct(name string) ([]string, error) {
+	field, ok := r.field(name)
+	if !ok {
+		return nil, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+
+	seen := make(map[string]struct{})
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+		seen[record.Data[r.dataKey(field)]] = struct{}{}
+	}
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values, nil
+}
+
+// scanField rewinds the reader and calls fn with the value of the given
+// field for every record.
+func (r *Reader) scanField(field Field, fn func(index uint32, value string) error) error {
+	if err := r.Rewind(); err != nil {
+		return fmt.Errorf("rewind: %w", err)
+	}
+
+	for r.Next() {
+		record, err := r.Read()
+		if err != nil {
+			return err
+		}
+		if err := fn(r.currentRecord-1, record.Data[r.dataKey(field)]); err != nil {
+			return err
+		}
+	}
+
+	return r.Err()
+}
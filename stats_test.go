@@ -0,0 +1,230 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// createDBFWithNumbersAndDates creates a DBF with a numeric and a date field
+// and one record per value pair; empty strings produce blank fields
+func createDBFWithNumbersAndDates(values [][2]string) []byte {
+	buf := new(bytes.Buffer)
+
+	buf.WriteByte(0x03)
+	buf.WriteByte(124)
+	buf.WriteByte(1)
+	buf.WriteByte(1)
+
+	binary.Write(buf, binary.LittleEndian, uint32(len(values)))
+	binary.Write(buf, binary.LittleEndian, uint16(32+32*2+1))
+	binary.Write(buf, binary.LittleEndian, uint16(1+8+8))
+	buf.Write(make([]byte, 20))
+
+	amount := append([]byte("AMOUNT"), make([]byte, 5)...)
+	buf.Write(amount)
+	buf.WriteByte('N')
+	buf.Write(make([]byte, 4))
+	buf.WriteByte(8)
+	buf.WriteByte(2)
+	buf.Write(make([]byte, 14))
+
+	paid := append([]byte("PAID"), make([]byte, 7)...)
+	buf.Write(paid)
+	buf.WriteByte('D')
+	buf.Write(make([]byte, 4))
+	buf.WriteByte(8)
+	buf.WriteByte(0)
+	buf.Write(make([]byte, 14))
+
+	buf.WriteByte(0x0D)
+
+	for _, v := range values {
+		buf.WriteByte(0x20)
+		buf.WriteString(padLeft(v[0], 8))
+		buf.WriteString(padLeft(v[1], 8))
+	}
+
+	return buf.Bytes()
+}
+
+// padLeft right-aligns s in a field of the given width
+func padLeft(s string, width int) string {
+	for len(s) < width {
+		s = " " + s
+	}
+	return s
+}
+
+func TestFieldStats(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"10.50", "20240105"},
+		{"", ""},
+		{"-2.00", "20231231"},
+		{"7.75", "20240301"},
+	})
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// partially consumed reader is rewound before scanning
+	dbf.Next()
+	if _, err := dbf.Read(); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+
+	stats, err := dbf.FieldStats("AMOUNT")
+	if err != nil {
+		t.Fatalf("FieldStats() failed: %v", err)
+	}
+
+	if stats.Count != 3 || stats.NullCount != 1 {
+		t.Errorf("Expected 3 values and 1 null, got %d and %d", stats.Count, stats.NullCount)
+	}
+	if stats.Min != -2 || stats.Max != 10.5 || stats.Sum != 16.25 {
+		t.Errorf("Unexpected min/max/sum: %v/%v/%v", stats.Min, stats.Max, stats.Sum)
+	}
+	if stats.Mean != 16.25/3 {
+		t.Errorf("Expected mean %v, got %v", 16.25/3, stats.Mean)
+	}
+}
+
+func TestDateStats(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"1", "20240105"},
+		{"2", ""},
+		{"3", "20231231"},
+	})
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	stats, err := dbf.DateStats("PAID")
+	if err != nil {
+		t.Fatalf("DateStats() failed: %v", err)
+	}
+
+	if stats.Count != 2 || stats.NullCount != 1 {
+		t.Errorf("Expected 2 values and 1 null, got %d and %d", stats.Count, stats.NullCount)
+	}
+	if !stats.Min.Equal(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected min: %v", stats.Min)
+	}
+	if !stats.Max.Equal(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected max: %v", stats.Max)
+	}
+}
+
+func TestFieldStatsErrors(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{{"1", "20240101"}})
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := dbf.FieldStats("MISSING"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+	if _, err := dbf.FieldStats("PAID"); err == nil {
+		t.Error("Expected error for numeric stats on a date field")
+	}
+	if _, err := dbf.DateStats("AMOUNT"); err == nil {
+		t.Error("Expected error for date stats on a numeric field")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"1.00", "20240101"},
+		{"2.00", "20240201"},
+		{"3.00", "20240101"},
+		{"", ""},
+	})
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	groups, err := dbf.GroupBy("PAID")
+	if err != nil {
+		t.Fatalf("GroupBy() failed: %v", err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+	if len(groups["20240101"]) != 2 || groups["20240101"][1].Data["AMOUNT"] != "3.00" {
+		t.Errorf("Unexpected group 20240101: %v", groups["20240101"])
+	}
+	if len(groups[""]) != 1 {
+		t.Errorf("Expected 1 record with blank date, got %d", len(groups[""]))
+	}
+
+	if _, err := dbf.GroupBy("MISSING"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"1.00", "20240201"},
+		{"2.00", "20240101"},
+		{"3.00", "20240201"},
+	})
+
+	dbf, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	values, err := dbf.Distinct("PAID")
+	if err != nil {
+		t.Fatalf("Distinct() failed: %v", err)
+	}
+	if len(values) != 2 || values[0] != "20240101" || values[1] != "20240201" {
+		t.Errorf("Expected [20240101 20240201], got %v", values)
+	}
+
+	if _, err := dbf.Distinct("MISSING"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestRewind(t *testing.T) {
+	dbf, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	first, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	if err := dbf.Rewind(); err != nil {
+		t.Fatalf("Rewind() failed: %v", err)
+	}
+
+	second, err := dbf.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() after Rewind() failed: %v", err)
+	}
+	if len(first) != 2 || len(second) != 2 || !first[1].Equal(second[1]) {
+		t.Errorf("Expected the same records after Rewind(), got %d and %d", len(first), len(second))
+	}
+
+	stream, err := New(streamOnly{bytes.NewReader(createMinimalDBF())}, WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := stream.Rewind(); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("Expected ErrNotSeekable, got %v", err)
+	}
+}
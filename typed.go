@@ -0,0 +1,251 @@
+package dbf
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The typed getters below parse the string value of a field using the field
+// metadata of the reader that produced the record. They re-parse the value on
+// every call, which keeps Read() cheap for fields that are never accessed.
+//
+// Each getter returns ok=false for null (blank) values, and an error wrapping
+// ErrUnknownField if the field does not exist in r, or if the value cannot be
+// converted.
+
+// ErrNumericOverflow is returned when a numeric field holds asterisks, which
+// FoxPro and dBASE write when a value does not fit the field width.
+var ErrNumericOverflow = errors.New("dbf: numeric overflow")
+
+// ParseNumeric parses the value of a numeric (N, F) field as returned by
+// Read(). A blank or null value returns ok=false and no error. A value made
+// of asterisks ("****") returns ok=false and ErrNumericOverflow, since the
+// real value was lost when the file was written. Only plain decimal
+// notation is accepted: exponents, hexadecimal numbers, NaN and Inf are an
+// error.
+//
+// Example:
+//
+//	amount, ok, err := reader.ParseNumeric(record.Data["AMOUNT"])
+//	if errors.Is(err, dbf.ErrNumericOverflow) {
+//		// treat as unknown
+//	}
+func (r *Reader) ParseNumeric(value string) (float64, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == r.nullString {
+		return 0, false, nil
+	}
+	if strings.Trim(value, "*") == "" {
+		return 0, false, ErrNumericOverflow
+	}
+
+	// a trailing dot ("5.") is written by some tools and is tolerated
+	if !isDecimal(strings.TrimSuffix(value, ".")) {
+		return 0, false, fmt.Errorf("%q is not a decimal number", value)
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return f, true, nil
+}
+
+// Int returns the value of a numeric (N, F) or integer (I) field as int64.
+// Values with a non-zero fractional part are an error.
+func (rec *Record) Int(r *Reader, name string) (int64, bool, error) {
+	value, ok, err := rec.typedValue(r, name, 'N', 'F', 'I')
+	if !ok || err != nil {
+		return 0, false, err
+	}
+
+	if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return v, true, nil
+	}
+	f, _, err := r.ParseNumeric(value)
+	if errors.Is(err, ErrNumericOverflow) {
+		return 0, false, fmt.Errorf("field %s: %w", name, err)
+	}
+	if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return 0, false, fmt.Errorf("field %s: %q is not an integer", name, value)
+	}
+	return int64(f), true, nil
+}
+
+// Float returns the value of a numeric (N, F) or integer (I) field as float64.
+func (rec *Record) Float(r *Reader, name string) (float64, bool, error) {
+	value, ok, err := rec.typedValue(r, name, 'N', 'F', 'I')
+	if !ok || err != nil {
+		return 0, false, err
+	}
+
+	f, _, err := r.ParseNumeric(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("field %s: %w", name, err)
+	}
+	return f, true, nil
+}
+
+// Time returns the value of a date (D) field, parsed with Reader.ParseDate.
+func (rec *Record) Time(r *Reader, name string) (time.Time, bool, error) {
+	value, ok, err := rec.typedValue(r, name, 'D')
+	if !ok || err != nil {
+		return time.Time{}, false, err
+	}
+
+	t, err := r.ParseDate(value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("field %s: %w", name, err)
+	}
+	return t, true, nil
+}
+
+// Bool returns the value of a logical (L) field.
+func (rec *Record) Bool(r *Reader, name string) (bool, bool, error) {
+	value, ok, err := rec.typedValue(r, name, 'L')
+	if !ok || err != nil {
+		return false, false, err
+	}
+
+	switch value {
+	case "true":
+		return true, true, nil
+	case "false":
+		return false, true, nil
+	default:
+		return false, false, fmt.Errorf("field %s: %q is not a logical value", name, value)
+	}
+}
+
+// Text returns the value of a field of any type. It is not named String so
+// that Record can implement fmt.Stringer.
+func (rec *Record) Text(r *Reader, name string) (string, bool, error) {
+	return rec.typedValue(r, name)
+}
+
+// typedValue returns the value of a field after checking that it exists and,
+// if types are given, that it has one of them. ok is false for null values.
+func (rec *Record) typedValue(r *Reader, name string, types ...byte) (string, bool, error) {
+	field, found := r.field(name)
+	if !found {
+		return "", false, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+
+	if len(types) > 0 && !slices.Contains(types, field.Type) {
+		return "", false, fmt.Errorf("field %s: cannot convert %s field", name, field.TypeString())
+	}
+
+	value := rec.Data[r.dataKey(field)]
+	if value == "" || value == r.nullString {
+		return "", false, nil
+	}
+	return value, true, nil
+}
+
+// Column reads all remaining records and returns the typed values of the
+// named field, decoding only that field: float64 for numeric (N, F) fields,
+// int64 for integer (I) fields, time.Time for dates, bool for logical fields
+// and string for all other types. Null (blank) values and numbers lost to
+// overflow (asterisks) are nil.
+// Deleted records are included unless the reader was created with
+// WithSkipDeleted.
+// Returns ErrUnknownField if the field does not exist.
+//
+// Example:
+//
+//	amounts, err := reader.Column("AMOUNT")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, v := range amounts {
+//		if amount, ok := v.(float64); ok {
+//			total += amount
+//		}
+//	}
+func (r *Reader) Column(name string) ([]any, error) {
+	field, ok := r.field(name)
+	if !ok {
+		return nil, fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+	offset, _ := r.fieldOffset(field.Name)
+
+	values := make([]any, 0, r.recordsCount-r.currentRecord)
+	for r.Next() {
+		value, err := r.readFieldValue(field, offset)
+		if err != nil {
+			return values, err
+		}
+
+		typed, err := r.columnValue(field, value)
+		if err != nil {
+			return values, fmt.Errorf("record %d: field %s: %w", r.currentRecord-1, name, err)
+		}
+		values = append(values, typed)
+	}
+
+	return values, r.Err()
+}
+
+// readFieldValue reads the current record and decodes only the given field,
+// which starts at offset within the record.
+func (r *Reader) readFieldValue(field Field, offset int) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	if err := r.checkCancelled(); err != nil {
+		return "", err
+	}
+
+	if r.segments != nil {
+		record, err := r.readSegment(false)
+		if err != nil {
+			return "", err
+		}
+		return record.Data[r.dataKey(field)], nil
+	}
+
+	recordBytes, err := r.readRecordBytes()
+	if err != nil {
+		return "", err
+	}
+	fieldData, err := fieldSlice(recordBytes, offset, field)
+	if err != nil {
+		return "", &FieldError{Field: field.Name, Err: err}
+	}
+	value, err := r.decodeFieldValue(field, fieldData)
+	if err != nil {
+		return "", &FieldError{Field: field.Name, Err: err}
+	}
+	if value == "" {
+		value = r.nullString
+	}
+	return value, nil
+}
+
+// columnValue converts a field value to the Go type used by Column.
+func (r *Reader) columnValue(field Field, value string) (any, error) {
+	if value == "" || value == r.nullString {
+		return nil, nil
+	}
+
+	switch field.Type {
+	case 'N', 'F':
+		f, _, err := r.ParseNumeric(value)
+		if errors.Is(err, ErrNumericOverflow) {
+			return nil, nil // the value was lost, like a blank one
+		}
+		return f, err
+	case 'I':
+		return strconv.ParseInt(value, 10, 64)
+	case 'D':
+		return r.ParseDate(value)
+	case 'L':
+		return value == "true", nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,186 @@
+package dbf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// createTypedRecord writes a record with all typed field kinds and reads it back
+func createTypedRecord(t *testing.T, data map[string]string) (*Reader, *Record) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, []Field{
+		{Name: "NAME", Type: 'C', Length: 10},
+		{Name: "QTY", Type: 'N', Length: 5},
+		{Name: "PRICE", Type: 'N', Length: 8, DecimalCount: 2},
+		{Name: "SOLD", Type: 'D', Length: 8},
+		{Name: "ACTIVE", Type: 'L', Length: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: data}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(buf.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	record, ok, err := reader.NextRecord()
+	if !ok || err != nil {
+		t.Fatalf("NextRecord() failed: ok=%v err=%v", ok, err)
+	}
+	return reader, record
+}
+
+func TestTypedGetters(t *testing.T) {
+	reader, rec := createTypedRecord(t, map[string]string{
+		"NAME": "Widget", "QTY": "12", "PRICE": "19.90", "SOLD": "20240315", "ACTIVE": "T",
+	})
+
+	if v, ok, err := rec.Int(reader, "QTY"); v != 12 || !ok || err != nil {
+		t.Errorf("Int(QTY): expected 12, got %d, %v, %v", v, ok, err)
+	}
+	if v, ok, err := rec.Float(reader, "PRICE"); v != 19.9 || !ok || err != nil {
+		t.Errorf("Float(PRICE): expected 19.9, got %v, %v, %v", v, ok, err)
+	}
+	if v, ok, err := rec.Time(reader, "SOLD"); !v.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) || !ok || err != nil {
+		t.Errorf("Time(SOLD): expected 2024-03-15, got %v, %v, %v", v, ok, err)
+	}
+	if v, ok, err := rec.Bool(reader, "ACTIVE"); !v || !ok || err != nil {
+		t.Errorf("Bool(ACTIVE): expected true, got %v, %v, %v", v, ok, err)
+	}
+	if v, ok, err := rec.Text(reader, "NAME"); v != "Widget" || !ok || err != nil {
+		t.Errorf("Text(NAME): expected 'Widget', got %q, %v, %v", v, ok, err)
+	}
+}
+
+func TestTypedGettersNullAndErrors(t *testing.T) {
+	reader, rec := createTypedRecord(t, map[string]string{"NAME": "Widget", "PRICE": "19.90"})
+
+	if _, ok, err := rec.Int(reader, "QTY"); ok || err != nil {
+		t.Errorf("Int(QTY): expected null, got ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := rec.Time(reader, "SOLD"); ok || err != nil {
+		t.Errorf("Time(SOLD): expected null, got ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := rec.Bool(reader, "ACTIVE"); ok || err != nil {
+		t.Errorf("Bool(ACTIVE): expected null, got ok=%v err=%v", ok, err)
+	}
+
+	if _, _, err := rec.Int(reader, "PRICE"); err == nil {
+		t.Error("Int(PRICE): expected error for fractional value")
+	}
+	if _, _, err := rec.Float(reader, "NAME"); err == nil {
+		t.Error("Float(NAME): expected error for character field")
+	}
+	if _, _, err := rec.Text(reader, "MISSING"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Text(MISSING): expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"12.50", "20240115"},
+		{"", ""},
+		{"-3.00", "20231231"},
+	})
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	amounts, err := reader.Column("AMOUNT")
+	if err != nil {
+		t.Fatalf("Column() failed: %v", err)
+	}
+	if len(amounts) != 3 || amounts[0] != 12.5 || amounts[1] != nil || amounts[2] != -3.0 {
+		t.Errorf("Expected [12.5 <nil> -3], got %v", amounts)
+	}
+
+	reader, err = New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	dates, err := reader.Column("PAID")
+	if err != nil {
+		t.Fatalf("Column() failed: %v", err)
+	}
+	if paid, ok := dates[2].(time.Time); !ok || !paid.Equal(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2023-12-31, got %v", dates[2])
+	}
+
+	if _, err := reader.Column("MISSING"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+func TestNumericOverflow(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"****", "20240115"},
+		{"********", "20240116"},
+		{"12.50", "20240117"},
+	})
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	for i, record := range records[:2] {
+		value := record.Data["AMOUNT"]
+		if _, ok, err := reader.ParseNumeric(value); ok || !errors.Is(err, ErrNumericOverflow) {
+			t.Errorf("Record %d: expected ErrNumericOverflow for %q, got ok=%v err=%v", i, value, ok, err)
+		}
+		if _, ok, err := record.Float(reader, "AMOUNT"); ok || !errors.Is(err, ErrNumericOverflow) {
+			t.Errorf("Record %d: Float() expected ErrNumericOverflow, got ok=%v err=%v", i, ok, err)
+		}
+		if _, _, err := record.Int(reader, "AMOUNT"); !errors.Is(err, ErrNumericOverflow) {
+			t.Errorf("Record %d: Int() expected ErrNumericOverflow, got %v", i, err)
+		}
+	}
+
+	if v, ok, err := reader.ParseNumeric(records[2].Data["AMOUNT"]); v != 12.5 || !ok || err != nil {
+		t.Errorf("Expected 12.5, got %v, %v, %v", v, ok, err)
+	}
+	if _, ok, err := reader.ParseNumeric("   "); ok || err != nil {
+		t.Errorf("Expected blank value to be null, got ok=%v err=%v", ok, err)
+	}
+	for _, value := range []string{"NaN", "Inf", "1e3", "0x1p4", "1_000"} {
+		if _, ok, err := reader.ParseNumeric(value); ok || err == nil {
+			t.Errorf("Expected error for %q, got ok=%v err=%v", value, ok, err)
+		}
+	}
+
+	// overflowed values are null in Column and FieldStats
+	reader, err = New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	column, err := reader.Column("AMOUNT")
+	if err != nil {
+		t.Fatalf("Column() failed: %v", err)
+	}
+	if len(column) != 3 || column[0] != nil || column[1] != nil || column[2] != 12.5 {
+		t.Errorf("Expected [nil nil 12.5], got %v", column)
+	}
+
+	stats, err := reader.FieldStats("AMOUNT")
+	if err != nil {
+		t.Fatalf("FieldStats() failed: %v", err)
+	}
+	if stats.Count != 1 || stats.NullCount != 2 || stats.Sum != 12.5 {
+		t.Errorf("Expected 1 value and 2 nulls summing to 12.5, got %+v", stats)
+	}
+}
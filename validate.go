@@ -0,0 +1,268 @@
+package dbf
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// ValidationError is returned by Field.Validate and Record.SetValue when a
+// value is not valid for the field it is assigned to.
+type ValidationError struct {
+	Field  string // name of the field
+	Value  string // rejected value
+	Reason string // why the value was rejected
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value %q for field %s: %s", e.Value, e.Field, e.Reason)
+}
+
+// SetValue stores value in the named field after checking it against the
+// field definition of the reader that produced the record: the value must
+// fit within the field length and be valid for the field type (a number for
+// N, F and I fields, YYYYMMDD for dates, a logical flag for L fields). Blank
+// values are always accepted.
+//
+// Changes are made in memory only; pass the record to Writer.AppendRecord
+// to persist them.
+// Returns ErrUnknownField if the field is not part of the record's schema,
+// which is always the case for records not created by a Reader, and a
+// *ValidationError if the value is rejected.
+//
+// Example:
+//
+//	if err := record.SetValue("PRICE", "19.90"); err != nil {
+//		log.Fatal(err)
+//	}
+//	err = writer.AppendRecord(record)
+func (rec *Record) SetValue(name, value string) error {
+	i := slices.IndexFunc(rec.fields, func(f Field) bool { return f.Name == name })
+	if i < 0 {
+		return fmt.Errorf("field %s: %w", name, ErrUnknownField)
+	}
+
+	if err := rec.fields[i].Validate(value); err != nil {
+		return err
+	}
+
+	if rec.Data == nil {
+		rec.Data = make(map[string]string, len(rec.fields))
+	}
+	rec.Data[name] = value
+	rec.folded = nil
+	return nil
+}
+
+// Validate checks that value, in the form returned by Reader.Read, can be
+// stored in the field without loss: character values must not be longer
+// than the field length in bytes (UTF-8 bytes, which is an upper bound for
+// the single-byte code pages), numeric (N, F) values must be plain decimal
+// numbers that fit the length and have no more decimals than the field
+// declares, dates must be valid
+// YYYYMMDD dates, logical values one of T, F, Y, N (either case), ?, true
+// or false, and integer (I) values must fit in 32 bits. Blank values are
+// always valid.
+// Returns a *ValidationError describing the first rule the value breaks.
+//
+// Example:
+//
+//	if err := field.Validate(input); err != nil {
+//		log.Printf("skipping row: %v", err)
+//	}
+func (f Field) Validate(value string) error {
+	if reason := invalidValue(f, value); reason != "" {
+		return &ValidationError{Field: f.Name, Value: value, Reason: reason}
+	}
+	return nil
+}
+
+// validateEncoded is like Validate, but checks character values against the
+// field length after encoding them with enc, as the Writer stores them.
+func (f Field) validateEncoded(value string, enc *encoding.Encoder) error {
+	switch f.Type {
+	case 'N', 'F', 'I', 'D', 'L', 'M', 'G':
+		return f.Validate(value)
+	}
+
+	encoded, err := enc.Bytes([]byte(value))
+	if err != nil {
+		return &ValidationError{Field: f.Name, Value: value, Reason: "cannot be encoded"}
+	}
+	if len(encoded) > int(f.Length) {
+		return &ValidationError{Field: f.Name, Value: value, Reason: fmt.Sprintf("longer than %d bytes when encoded", f.Length)}
+	}
+	return nil
+}
+
+// invalidValue returns why value cannot be stored in field, or "" if it can.
+func invalidValue(field Field, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+
+	switch field.Type {
+	case 'N', 'F':
+		if len(value) > int(field.Length) {
+			return fmt.Sprintf("longer than %d characters", field.Length)
+		}
+		number := strings.TrimSpace(value)
+		if !isDecimal(number) {
+			return "not a decimal number"
+		}
+		if dot := strings.IndexByte(number, '.'); dot >= 0 && len(number)-dot-1 > int(field.DecimalCount) {
+			return fmt.Sprintf("more than %d decimals", field.DecimalCount)
+		}
+
+	case 'I':
+		if _, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32); err != nil {
+			return "not a 32-bit integer"
+		}
+
+	case 'D':
+		if _, err := time.Parse("20060102", value); err != nil {
+			return "not a date in YYYYMMDD format"
+		}
+
+	case 'L':
+		switch value {
+		case "true", "false", "T", "t", "Y", "y", "F", "f", "N", "n", "?":
+		default:
+			return "not a logical value"
+		}
+
+	case 'M', 'G':
+		// memo text is stored in the memo file
+
+	default:
+		if len(value) > int(field.Length) {
+			return fmt.Sprintf("longer than %d bytes", field.Length)
+		}
+	}
+
+	return ""
+}
+
+// isDecimal reports whether s is a plain decimal number: an optional sign,
+// digits and an optional fraction of a dot followed by digits. Unlike
+// strconv.ParseFloat it rejects exponents, hexadecimal notation,
+// underscores, NaN and Inf, which cannot be stored in a numeric field.
+func isDecimal(s string) bool {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	whole, fraction, hasDot := strings.Cut(s, ".")
+	if hasDot && fraction == "" || whole == "" && fraction == "" {
+		return false
+	}
+	for _, part := range []string{whole, fraction} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RecordError describes a field value that does not conform to its field
+// definition, as reported by ValidateRecords.
+type RecordError struct {
+	Index uint32 // physical record index (0-based)
+	Field string // name of the field, as used in Record.Data
+	Value string // stored value with its padding
+	Err   error  // description of the violation
+}
+
+// Error implements the error interface.
+func (e RecordError) Error() string {
+	return fmt.Sprintf("record %d: field %s: %v", e.Index, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e RecordError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateRecords reads all remaining records and checks every value against
+// its field definition: numeric (N, F) and integer (I) values must parse as
+// numbers with no more decimals than the field declares, dates must be valid
+// and logical values must be one of T, t, Y, y, F, f, N, n, ? or blank.
+// Blank values are always valid.
+//
+// The returned slice lists every violation found; the error is only set if
+// the records cannot be read. Deleted records are checked unless the reader
+// was created with WithSkipDeleted.
+//
+// Example:
+//
+//	violations, err := reader.ValidateRecords()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, v := range violations {
+//		log.Println(v)
+//	}
+func (r *Reader) ValidateRecords() ([]RecordError, error) {
+	var violations []RecordError
+
+	for r.Next() {
+		index := r.currentRecord - 1
+		record, err := r.ReadWithRaw()
+		if err != nil {
+			return violations, err
+		}
+
+		for _, field := range r.fields {
+			key := r.dataKey(field)
+			if err := r.validateValue(record, field, key); err != nil {
+				violations = append(violations, RecordError{
+					Index: index,
+					Field: key,
+					Value: record.RawString(key),
+					Err:   err,
+				})
+			}
+		}
+	}
+
+	return violations, r.Err()
+}
+
+// validateValue checks a single value of record using the typed getters.
+func (r *Reader) validateValue(record *Record, field Field, key string) error {
+	switch field.Type {
+	case 'N', 'F':
+		if _, _, err := record.Float(r, key); err != nil {
+			return err
+		}
+		value := record.Data[key]
+		if dot := strings.IndexByte(value, '.'); dot >= 0 && len(value)-dot-1 > int(field.DecimalCount) {
+			return fmt.Errorf("%q has more than %d decimals", value, field.DecimalCount)
+		}
+
+	case 'I':
+		if _, _, err := record.Int(r, key); err != nil {
+			return err
+		}
+
+	case 'D':
+		if _, _, err := record.Time(r, key); err != nil {
+			return err
+		}
+
+	case 'L':
+		// invalid flags decode as null, so check the stored value
+		flag := strings.TrimSpace(record.RawString(key))
+		if flag != "" && !strings.Contains("TtYyFfNn?", flag) {
+			return fmt.Errorf("%q is not a logical value", flag)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package dbf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestValidateRecords(t *testing.T) {
+	data := createDBFWithNumbersAndDates([][2]string{
+		{"12.50", "20240115"},
+		{"abc", "20241399"},
+		{"1.234", ""},
+		{"", ""},
+	})
+
+	reader, err := New(bytes.NewReader(data), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	violations, err := reader.ValidateRecords()
+	if err != nil {
+		t.Fatalf("ValidateRecords() failed: %v", err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 violations, got %d: %v", len(violations), violations)
+	}
+
+	expected := []struct {
+		index uint32
+		field string
+	}{{1, "AMOUNT"}, {1, "PAID"}, {2, "AMOUNT"}}
+	for i, want := range expected {
+		if violations[i].Index != want.index || violations[i].Field != want.field {
+			t.Errorf("Violation %d: expected record %d field %s, got %v", i, want.index, want.field, violations[i])
+		}
+	}
+	if violations[0].Value != "     abc" {
+		t.Errorf("Expected padded value '     abc', got %q", violations[0].Value)
+	}
+}
+
+func TestValidateRecordsLogical(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, []Field{{Name: "ACTIVE", Type: 'L', Length: 1}})
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	for _, value := range []string{"T", "F", ""} {
+		if err := writer.AppendRecord(&Record{Data: map[string]string{"ACTIVE": value}}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	data := buf.Bytes()
+	data[len(data)-2] = 'X' // last record holds an invalid flag
+
+	reader, err := NewFromBytes(data, WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	violations, err := reader.ValidateRecords()
+	if err != nil {
+		t.Fatalf("ValidateRecords() failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Index != 2 || violations[0].Field != "ACTIVE" {
+		t.Errorf("Expected one violation in record 2, got %v", violations)
+	}
+}
+
+func TestRecordSetValue(t *testing.T) {
+	_, rec := createTypedRecord(t, map[string]string{
+		"NAME": "Widget", "QTY": "12", "PRICE": "19.90", "SOLD": "20240315", "ACTIVE": "T",
+	})
+
+	valid := map[string]string{
+		"NAME":   "Gadget",
+		"QTY":    "-3",
+		"PRICE":  "5.5",
+		"SOLD":   "20241231",
+		"ACTIVE": "false",
+	}
+	for name, value := range valid {
+		if err := rec.SetValue(name, value); err != nil {
+			t.Errorf("SetValue(%s, %q) failed: %v", name, value, err)
+		}
+		if rec.Data[name] != value {
+			t.Errorf("Expected %s to be %q, got %q", name, value, rec.Data[name])
+		}
+	}
+
+	invalid := map[string]string{
+		"NAME":   "much too long name",
+		"QTY":    "123456",
+		"PRICE":  "1.999",
+		"SOLD":   "20241301",
+		"ACTIVE": "maybe",
+	}
+	for name, value := range invalid {
+		var validationErr *ValidationError
+		if err := rec.SetValue(name, value); !errors.As(err, &validationErr) {
+			t.Errorf("SetValue(%s, %q): expected *ValidationError, got %v", name, value, err)
+		}
+		if rec.Data[name] == value {
+			t.Errorf("Expected %s to keep its previous value", name)
+		}
+	}
+
+	if err := rec.SetValue("MISSING", "x"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+	if err := (&Record{}).SetValue("NAME", "x"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField for a record without schema, got %v", err)
+	}
+}
+
+func TestFieldValidate(t *testing.T) {
+	tests := []struct {
+		field Field
+		value string
+		valid bool
+	}{
+		{Field{Name: "NAME", Type: 'C', Length: 5}, "Alice", true},
+		{Field{Name: "NAME", Type: 'C', Length: 5}, "Alice!", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 6, DecimalCount: 2}, "123.45", true},
+		{Field{Name: "PRICE", Type: 'N', Length: 6, DecimalCount: 2}, "1234.5", true},
+		{Field{Name: "PRICE", Type: 'N', Length: 6, DecimalCount: 2}, "12345.6", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 6, DecimalCount: 2}, "1.234", false},
+		{Field{Name: "PRICE", Type: 'F', Length: 6, DecimalCount: 2}, "12a", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 6, DecimalCount: 2}, " -.5", true},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "NaN", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "Inf", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "1e3", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "0x1p4", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "1_000", false},
+		{Field{Name: "PRICE", Type: 'N', Length: 5}, "5.", false},
+		{Field{Name: "NAME", Type: 'C', Length: 4}, "Жара", false}, // 8 UTF-8 bytes
+		{Field{Name: "SOLD", Type: 'D', Length: 8}, "20240229", true},
+		{Field{Name: "SOLD", Type: 'D', Length: 8}, "        ", true},
+		{Field{Name: "SOLD", Type: 'D', Length: 8}, "20230229", false},
+		{Field{Name: "ACTIVE", Type: 'L', Length: 1}, "y", true},
+		{Field{Name: "ACTIVE", Type: 'L', Length: 1}, " ", true},
+		{Field{Name: "ACTIVE", Type: 'L', Length: 1}, "X", false},
+		{Field{Name: "QTY", Type: 'I', Length: 4}, "-2147483648", true},
+		{Field{Name: "QTY", Type: 'I', Length: 4}, "2147483648", false},
+	}
+
+	for _, tt := range tests {
+		err := tt.field.Validate(tt.value)
+		if tt.valid && err != nil {
+			t.Errorf("Validate(%q) on %c field failed: %v", tt.value, tt.field.Type, err)
+		}
+		if !tt.valid {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Validate(%q) on %c field: expected *ValidationError, got %v", tt.value, tt.field.Type, err)
+			} else if validationErr.Field != tt.field.Name {
+				t.Errorf("Expected field %s in error, got %s", tt.field.Name, validationErr.Field)
+			}
+		}
+	}
+}
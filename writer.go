@@ -0,0 +1,608 @@
+package dbf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Writer writes records to a new DBF file (dBASE III format).
+//
+// The header is written by NewWriter. If the destination implements
+// io.WriteSeeker (e.g. *os.File), Close updates the record count in the
+// header; otherwise the header keeps a record count of 0 and the file can be
+// read back with WithRecordCountFromFile().
+type Writer struct {
+	w          io.Writer
+	fields     []Field
+	encoder    *encoding.Encoder
+	ldid       byte
+	lastUpdate time.Time
+	recordSize uint16
+	count      uint32
+	buf        []byte
+	closed     bool
+
+	memoPath      string
+	memo          *os.File
+	memoDBT       bool   // dBASE III .dbt layout instead of FoxPro .fpt
+	memoBlockSize uint32 // bytes per memo block
+	memoNextBlock uint32 // next free memo block
+
+	strict bool // validate values before writing them
+}
+
+// memoHeaderSize is the size of the header of .dbt and .fpt memo files.
+const memoHeaderSize = 512
+
+// WriterOption is a functional option for configuring a Writer.
+type WriterOption func(*Writer)
+
+// WithWriterEncoding sets the text encoding used for character fields.
+// The Language Driver ID is set accordingly for encodings that have one.
+// The default is Windows-1252.
+func WithWriterEncoding(e encoding.Encoding) WriterOption {
+	return func(w *Writer) {
+		w.encoder = e.NewEncoder()
+		w.ldid = ldidForEncoding(e)
+	}
+}
+
+// WithWriterLastUpdate sets the last update date stored in the header.
+// The default is the current date.
+func WithWriterLastUpdate(t time.Time) WriterOption {
+	return func(w *Writer) {
+		w.lastUpdate = t
+	}
+}
+
+// WithMemoOutput writes the contents of memo ('M') fields to a memo file at
+// path instead of storing block numbers given by the caller. The file layout
+// follows the extension: dBASE III for ".dbt" (512-byte blocks), FoxPro for
+// anything else, typically ".fpt" (64-byte blocks). The memo file is created
+// by NewWriter and finalized and closed by Close.
+func WithMemoOutput(path string) WriterOption {
+	return func(w *Writer) {
+		w.memoPath = path
+	}
+}
+
+// WithStrictValidation makes AppendRecord check every value with
+// Field.Validate before writing the record, so that values that do not fit
+// are rejected with a *ValidationError instead of being truncated. Character
+// values are measured after encoding them with the writer's encoding, so
+// multi-byte characters are never cut. Nothing is written for a rejected
+// record.
+//
+// Example:
+//
+//	writer, err := dbf.NewWriter(file, fields, dbf.WithStrictValidation())
+//	...
+//	if err := writer.AppendRecord(rec); err != nil {
+//		var invalid *dbf.ValidationError
+//		if errors.As(err, &invalid) {
+//			log.Printf("rejected %s: %s", invalid.Field, invalid.Reason)
+//		}
+//	}
+func WithStrictValidation() WriterOption {
+	return func(w *Writer) {
+		w.strict = true
+	}
+}
+
+// NewWriter validates the field definitions and writes the DBF header to w.
+//
+// Example:
+//
+//	file, _ := os.Create("out.dbf")
+//	defer file.Close()
+//
+//	writer, err := dbf.NewWriter(file, []dbf.Field{
+//		dbf.NewCharField("NAME", 20),
+//		dbf.NewNumericField("AGE", 3, 0),
+//	}, dbf.WithWriterEncoding(charmap.CodePage866))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	err = writer.AppendRecord(&dbf.Record{Data: map[string]string{"NAME": "Alice", "AGE": "25"}})
+//	...
+//	err = writer.Close()
+func NewWriter(w io.Writer, fields []Field, opts ...WriterOption) (*Writer, error) {
+	writer := &Writer{
+		w:          w,
+		fields:     fields,
+		encoder:    charmap.Windows1252.NewEncoder(),
+		ldid:       0x03,
+		lastUpdate: time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(writer)
+	}
+
+	if err := validateFields(fields); err != nil {
+		return nil, fmt.Errorf("invalid fields: %w", err)
+	}
+
+	size := 1 // deletion flag
+	for _, field := range fields {
+		size += int(field.Length)
+	}
+	if size > 0xFFFF {
+		return nil, fmt.Errorf("invalid fields: record size %d exceeds 65535 bytes", size)
+	}
+	writer.recordSize = uint16(size)
+	writer.buf = make([]byte, size)
+
+	if writer.memoPath != "" {
+		if err := writer.createMemo(); err != nil {
+			return nil, fmt.Errorf("create memo file: %w", err)
+		}
+	}
+
+	if err := writer.writeHeader(); err != nil {
+		if writer.memo != nil {
+			_ = writer.memo.Close()
+		}
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+
+	return writer, nil
+}
+
+// NewCharField returns the definition of a character (C) field.
+// Field definitions are validated by NewWriter: names must be 1 to 10 bytes
+// long and lengths must fit the field type.
+func NewCharField(name string, length byte) Field {
+	return Field{Name: name, RawName: name, Type: 'C', Length: length}
+}
+
+// NewNumericField returns the definition of a numeric (N) field with the
+// given total length (including sign and decimal point) and decimal count.
+func NewNumericField(name string, length, decimals byte) Field {
+	return Field{Name: name, RawName: name, Type: 'N', Length: length, DecimalCount: decimals}
+}
+
+// NewDateField returns the definition of a date (D) field, always 8 bytes long.
+func NewDateField(name string) Field {
+	return Field{Name: name, RawName: name, Type: 'D', Length: 8}
+}
+
+// NewLogicalField returns the definition of a logical (L) field, always 1 byte long.
+func NewLogicalField(name string) Field {
+	return Field{Name: name, RawName: name, Type: 'L', Length: 1}
+}
+
+// validateFields checks that field definitions can be written to a DBF header.
+func validateFields(fields []Field) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields defined")
+	}
+	if 32+32*len(fields)+1 > 0xFFFF {
+		return fmt.Errorf("too many fields: %d", len(fields))
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		if field.Name == "" || len(field.Name) > 10 {
+			return fmt.Errorf("field %d: name %q must be 1 to 10 bytes long", i, field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("field %d: duplicate name %q", i, field.Name)
+		}
+		seen[field.Name] = true
+
+		if field.Length == 0 {
+			return fmt.Errorf("field %s: length must be positive", field.Name)
+		}
+
+		switch field.Type {
+		case 'C', 'M', 'G':
+		case 'N', 'F':
+			if field.Length > 20 {
+				return fmt.Errorf("field %s: numeric length %d exceeds 20", field.Name, field.Length)
+			}
+			if field.DecimalCount > 0 && int(field.DecimalCount) > int(field.Length)-2 {
+				return fmt.Errorf("field %s: %d decimals do not fit in length %d", field.Name, field.DecimalCount, field.Length)
+			}
+		case 'D':
+			if field.Length != 8 {
+				return fmt.Errorf("field %s: date length must be 8, got %d", field.Name, field.Length)
+			}
+		case 'L':
+			if field.Length != 1 {
+				return fmt.Errorf("field %s: logical length must be 1, got %d", field.Name, field.Length)
+			}
+		default:
+			return fmt.Errorf("field %s: unsupported type %q", field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
+
+// writeHeader writes the 32-byte file header, the field descriptors and the terminator.
+func (w *Writer) writeHeader() error {
+	headerSize := 32 + 32*len(w.fields) + 1
+	header := make([]byte, headerSize)
+
+	header[0] = byte(FoxBASEPlusNoMemo)
+	for _, field := range w.fields {
+		if field.Type == 'M' || field.Type == 'G' {
+			header[0] = byte(FoxBASEPlusMemo)
+			break
+		}
+	}
+	if w.memo != nil && !w.memoDBT {
+		header[0] = byte(FoxPro2)
+	}
+
+	header[1] = byte(w.lastUpdate.Year() - 1900)
+	header[2] = byte(w.lastUpdate.Month())
+	header[3] = byte(w.lastUpdate.Day())
+	binary.LittleEndian.PutUint32(header[4:8], 0)
+	binary.LittleEndian.PutUint16(header[8:10], uint16(headerSize))
+	binary.LittleEndian.PutUint16(header[10:12], w.recordSize)
+	header[29] = w.ldid
+
+	for i, field := range w.fields {
+		descriptor := header[32+32*i : 64+32*i]
+		copy(descriptor[0:11], field.Name)
+		descriptor[11] = field.Type
+		descriptor[16] = field.Length
+		descriptor[17] = field.DecimalCount
+	}
+	header[headerSize-1] = 0x0D
+
+	_, err := w.w.Write(header)
+	return err
+}
+
+// Fields returns the field definitions of the file being written.
+func (w *Writer) Fields() []Field {
+	return w.fields
+}
+
+// RecordsCount returns the number of records written so far.
+func (w *Writer) RecordsCount() uint32 {
+	return w.count
+}
+
+// AppendRecord encodes a record and writes it to the file.
+// Values are taken from rec.Data by field name; missing fields are written
+// blank. Values longer than the field are truncated, except numeric values,
+// which are replaced by asterisks as FoxPro does on overflow. With
+// WithStrictValidation, such values are rejected instead. Memo (M, G) fields
+// of length 4 store the block number in binary, as Visual FoxPro does.
+func (w *Writer) AppendRecord(rec *Record) error {
+	if w.closed {
+		return fmt.Errorf("append record: writer is closed")
+	}
+
+	if w.strict {
+		for _, field := range w.fields {
+			if err := field.validateEncoded(rec.Data[field.Name], w.encoder); err != nil {
+				return fmt.Errorf("append record: %w", err)
+			}
+		}
+	}
+
+	w.buf[0] = 0x20
+	if rec.Deleted {
+		w.buf[0] = 0x2A
+	}
+
+	offset := 1
+	for _, field := range w.fields {
+		data := w.buf[offset : offset+int(field.Length)]
+		offset += int(field.Length)
+
+		if err := w.encodeFieldValue(field, rec.Data[field.Name], data); err != nil {
+			return fmt.Errorf("encode field %s: %w", field.Name, err)
+		}
+	}
+
+	if _, err := w.w.Write(w.buf); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	w.count++
+
+	return nil
+}
+
+// encodeFieldValue writes the string value of a field into data,
+// padded to the field length.
+func (w *Writer) encodeFieldValue(field Field, value string, data []byte) error {
+	for i := range data {
+		data[i] = ' '
+	}
+
+	if field.Type == 'M' && w.memo != nil && value != "" {
+		block, err := w.writeMemo(value)
+		if err != nil {
+			return fmt.Errorf("write memo: %w", err)
+		}
+		value = strconv.FormatUint(uint64(block), 10)
+	}
+
+	if (field.Type == 'M' || field.Type == 'G') && len(data) == 4 {
+		// Visual FoxPro style binary block number, 0 for no memo
+		clear(data)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil
+		}
+		block, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid memo block %q", value)
+		}
+		binary.LittleEndian.PutUint32(data, uint32(block))
+		return nil
+	}
+
+	switch field.Type {
+	case 'N', 'F', 'M', 'G': // right-aligned numbers and memo block references
+		value = strings.TrimSpace(value)
+		if len(value) > len(data) {
+			copy(data, bytes.Repeat([]byte{'*'}, len(data)))
+			return nil
+		}
+		copy(data[len(data)-len(value):], value)
+
+	case 'L':
+		switch value {
+		case "true", "T", "t", "Y", "y":
+			data[0] = 'T'
+		case "false", "F", "f", "N", "n":
+			data[0] = 'F'
+		case "":
+		default:
+			data[0] = '?'
+		}
+
+	default: // character and date fields are left-aligned
+		encoded, err := w.encoder.Bytes([]byte(value))
+		if err != nil {
+			return err
+		}
+		copy(data, encoded)
+	}
+
+	return nil
+}
+
+// Close writes the end-of-file marker and, if the destination implements
+// io.WriteSeeker, updates the record count in the header. A memo file created
+// with WithMemoOutput is finalized and closed; the underlying writer is not.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.memo != nil {
+		if err := w.closeMemo(); err != nil {
+			return fmt.Errorf("finalize memo file: %w", err)
+		}
+	}
+
+	if _, err := w.w.Write([]byte{0x1A}); err != nil {
+		return fmt.Errorf("write end-of-file marker: %w", err)
+	}
+
+	ws, ok := w.w.(io.WriteSeeker)
+	if !ok {
+		return nil
+	}
+
+	end, err := ws.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("seek: %w", err)
+	}
+	headerStart := end - int64(32+32*len(w.fields)+1) - int64(w.count)*int64(w.recordSize) - 1
+	if _, err := ws.Seek(headerStart+4, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to record count: %w", err)
+	}
+	if err := binary.Write(ws, binary.LittleEndian, w.count); err != nil {
+		return fmt.Errorf("write record count: %w", err)
+	}
+	if _, err := ws.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to end: %w", err)
+	}
+
+	return nil
+}
+
+// createMemo creates the memo file and reserves its 512-byte header.
+func (w *Writer) createMemo() error {
+	w.memoDBT = strings.EqualFold(filepath.Ext(w.memoPath), ".dbt")
+	w.memoBlockSize = 64
+	if w.memoDBT {
+		w.memoBlockSize = 512
+	}
+	w.memoNextBlock = memoHeaderSize / w.memoBlockSize
+
+	file, err := os.Create(w.memoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(make([]byte, memoHeaderSize)); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	w.memo = file
+	return nil
+}
+
+// writeMemo appends text to the memo file and returns its first block number.
+func (w *Writer) writeMemo(text string) (uint32, error) {
+	encoded, err := w.encoder.Bytes([]byte(text))
+	if err != nil {
+		return 0, err
+	}
+
+	var block []byte
+	if w.memoDBT {
+		// dBASE III: text terminated by two 0x1A bytes
+		block = append(encoded, 0x1A, 0x1A)
+	} else {
+		// FoxPro: 4-byte type (1 = text) and 4-byte length, big-endian
+		block = make([]byte, 8, 8+len(encoded))
+		binary.BigEndian.PutUint32(block[0:4], 1)
+		binary.BigEndian.PutUint32(block[4:8], uint32(len(encoded)))
+		block = append(block, encoded...)
+	}
+
+	blocks := (uint32(len(block)) + w.memoBlockSize - 1) / w.memoBlockSize
+	block = append(block, make([]byte, blocks*w.memoBlockSize-uint32(len(block)))...)
+
+	if _, err := w.memo.Write(block); err != nil {
+		return 0, err
+	}
+
+	number := w.memoNextBlock
+	w.memoNextBlock += blocks
+	return number, nil
+}
+
+// closeMemo writes the memo file header and closes the file.
+func (w *Writer) closeMemo() error {
+	header := make([]byte, 8)
+	if w.memoDBT {
+		binary.LittleEndian.PutUint32(header[0:4], w.memoNextBlock)
+	} else {
+		binary.BigEndian.PutUint32(header[0:4], w.memoNextBlock)
+		binary.BigEndian.PutUint16(header[6:8], uint16(w.memoBlockSize))
+	}
+
+	if _, err := w.memo.WriteAt(header, 0); err != nil {
+		_ = w.memo.Close()
+		return err
+	}
+	return w.memo.Close()
+}
+
+// ldidForEncoding returns the Language Driver ID of a known encoding, or 0.
+func ldidForEncoding(e encoding.Encoding) byte {
+	switch e {
+	case charmap.CodePage437:
+		return 0x01
+	case charmap.CodePage850:
+		return 0x02
+	case charmap.Windows1252:
+		return 0x03
+	case charmap.CodePage866:
+		return 0x26
+	case charmap.Windows1251:
+		return 0xC9
+	default:
+		return 0x00
+	}
+}
+
+// Pipe streams the remaining records of r into w one at a time, without
+// holding them in memory. The schemas of r and w must be compatible,
+// otherwise ErrSchemaMismatch is returned and nothing is written. Values
+// are re-encoded with the writer's encoding, so Pipe also converts a table
+// from one code page to another.
+// Deleted records are copied unless r was created with WithSkipDeleted.
+// If r has a memo file and w was created with WithMemoOutput, memo ('M')
+// fields are copied as text into the new memo file; otherwise their block
+// numbers are copied unchanged.
+//
+// Pipe does not close w.
+//
+// Example:
+//
+//	writer, _ := dbf.NewWriter(out, reader.Fields(), dbf.WithWriterEncoding(charmap.Windows1251))
+//	if err := reader.Pipe(writer); err != nil {
+//		log.Fatal(err)
+//	}
+//	err = writer.Close()
+func (r *Reader) Pipe(w *Writer) error {
+	if !r.Schema().Compatible(w.Schema()) {
+		return fmt.Errorf("pipe: %w", ErrSchemaMismatch)
+	}
+	if !copiesMemoText(r, w) {
+		return r.PipeRecords(context.Background(), w.AppendRecord)
+	}
+	return r.PipeRecords(context.Background(), func(record *Record) error {
+		if err := r.resolveMemoText(record); err != nil {
+			return err
+		}
+		return w.AppendRecord(record)
+	})
+}
+
+// copiesMemoText reports whether memo fields read from r must be resolved
+// to text before they are written to w, which stores memo text itself.
+func copiesMemoText(r *Reader, w *Writer) bool {
+	return r.memo != nil && w.memo != nil
+}
+
+// Transform copies records from src to dst, passing each one through fn.
+// If fn returns a nil record, the record is skipped; if it returns an error,
+// Transform stops and returns the error annotated with the record index.
+// Returning ErrStopIteration stops Transform without an error; records already
+// passed to dst are kept.
+// Deleted records are passed to fn unless src was created with WithSkipDeleted.
+// If src has a memo file and dst was created with WithMemoOutput, memo ('M')
+// fields are resolved to their text before fn is called, so that dst stores
+// the text in its own memo file.
+//
+// Transform does not close dst.
+//
+// Example:
+//
+//	err := dbf.Transform(reader, writer, func(rec *dbf.Record) (*dbf.Record, error) {
+//		if rec.Data["STATUS"] != "A" {
+//			return nil, nil // filter out
+//		}
+//		rec.Data["NAME"] = strings.ToUpper(rec.Data["NAME"])
+//		return rec, nil
+//	})
+func Transform(src *Reader, dst *Writer, fn func(*Record) (*Record, error)) error {
+	for src.Next() {
+		index := src.currentRecord - 1
+
+		record, err := src.Read()
+		if err != nil {
+			return fmt.Errorf("read record %d: %w", index, err)
+		}
+		if copiesMemoText(src, dst) {
+			if err := src.resolveMemoText(record); err != nil {
+				return fmt.Errorf("read record %d: %w", index, err)
+			}
+		}
+
+		record, err = fn(record)
+		if errors.Is(err, ErrStopIteration) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("transform record %d: %w", index, err)
+		}
+		if record == nil {
+			continue
+		}
+
+		if err := dst.AppendRecord(record); err != nil {
+			return fmt.Errorf("write record %d: %w", index, err)
+		}
+	}
+
+	return src.Err()
+}
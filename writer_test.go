@@ -0,0 +1,642 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// testWriterFields is the schema used by the writer tests
+var testWriterFields = []Field{
+	{Name: "NAME", Type: 'C', Length: 10},
+	{Name: "AGE", Type: 'N', Length: 3},
+	{Name: "BIRTHDATE", Type: 'D', Length: 8},
+	{Name: "ACTIVE", Type: 'L', Length: 1},
+}
+
+// createTestWriter creates a writer on a temporary file and returns it with the file path
+func createTestWriter(t *testing.T, fields []Field, opts ...WriterOption) (*Writer, *os.File) {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.dbf"))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	t.Cleanup(func() { file.Close() })
+
+	writer, err := NewWriter(file, fields, opts...)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	return writer, file
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	writer, file := createTestWriter(t, testWriterFields,
+		WithWriterEncoding(charmap.CodePage866),
+		WithWriterLastUpdate(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)))
+
+	records := []*Record{
+		{Data: map[string]string{"NAME": "Иван", "AGE": "42", "BIRTHDATE": "19820115", "ACTIVE": "true"}},
+		{Deleted: true, Data: map[string]string{"NAME": "Jane", "AGE": "7", "ACTIVE": "false"}},
+	}
+	for _, rec := range records {
+		if err := writer.AppendRecord(rec); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.RecordsCount() != 2 {
+		t.Errorf("Expected 2 records, got %d", reader.RecordsCount())
+	}
+	if reader.LDID() != 0x26 {
+		t.Errorf("Expected LDID 0x26, got 0x%02X", reader.LDID())
+	}
+	if !reader.LastUpdate().Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected last update 2024-03-15, got %v", reader.LastUpdate())
+	}
+	if !SchemaEqual(reader.Fields(), testWriterFields) {
+		t.Errorf("Expected fields %v, got %v", testWriterFields, reader.Fields())
+	}
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+
+	expected := []*Record{
+		{Data: map[string]string{"NAME": "Иван", "AGE": "42", "BIRTHDATE": "19820115", "ACTIVE": "true"}},
+		{Deleted: true, Data: map[string]string{"NAME": "Jane", "AGE": "7", "BIRTHDATE": "", "ACTIVE": "false"}},
+	}
+	for i := range expected {
+		if !got[i].Equal(expected[i]) {
+			t.Errorf("Record %d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestWriterNonSeekable(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, testWriterFields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"NAME": "John"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromBytes(buf.Bytes(), WithRecordCountFromFile())
+	if err != nil {
+		t.Fatalf("NewFromBytes() failed: %v", err)
+	}
+	if reader.RecordsCount() != 1 {
+		t.Errorf("Expected 1 record, got %d", reader.RecordsCount())
+	}
+}
+
+func TestWriterNumericOverflow(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, testWriterFields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"AGE": "1234"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+
+	headerSize := 32 + 32*len(testWriterFields) + 1
+	age := string(buf.Bytes()[headerSize+11 : headerSize+14])
+	if age != "***" {
+		t.Errorf("Expected '***', got %q", age)
+	}
+}
+
+func TestNewWriterInvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+	}{
+		{"no fields", nil},
+		{"long name", []Field{{Name: "VERYLONGNAME", Type: 'C', Length: 10}}},
+		{"duplicate name", []Field{{Name: "A", Type: 'C', Length: 1}, {Name: "A", Type: 'C', Length: 1}}},
+		{"zero length", []Field{{Name: "A", Type: 'C'}}},
+		{"bad date length", []Field{{Name: "A", Type: 'D', Length: 6}}},
+		{"unsupported type", []Field{{Name: "A", Type: 'X', Length: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWriter(&bytes.Buffer{}, tt.fields); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	writer, file := createTestWriter(t, reader.Fields())
+
+	err = Transform(reader, writer, func(rec *Record) (*Record, error) {
+		if rec.Deleted {
+			return nil, nil
+		}
+		rec.Data["NAME"] = strings.ToUpper(rec.Data["NAME"])
+		return rec, nil
+	})
+	if err != nil {
+		t.Fatalf("Transform() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	out, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer out.Close()
+
+	records, err := out.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "JOHN DOE" {
+		t.Errorf("Expected 'JOHN DOE', got '%s'", records[0].Data["NAME"])
+	}
+}
+
+func TestPipe(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// re-encode from CP866 to Windows-1251
+	writer, file := createTestWriter(t, reader.Fields(), WithWriterEncoding(charmap.Windows1251))
+	if err := reader.Pipe(writer); err != nil {
+		t.Fatalf("Pipe() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	out, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer out.Close()
+
+	records, err := out.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Data["NAME"] != "John Doe" || !records[1].Deleted {
+		t.Errorf("Unexpected records: %v, %v", records[0], records[1])
+	}
+}
+
+func TestPipeMulti(t *testing.T) {
+	first := writeTestFile(t, "a.dbf", createMinimalDBF())
+	second := writeTestFile(t, "b.dbf", createMinimalDBF())
+
+	reader, err := OpenMulti([]string{first, second}, WithCP866())
+	if err != nil {
+		t.Fatalf("OpenMulti() failed: %v", err)
+	}
+	defer reader.Close()
+
+	writer, file := createTestWriter(t, reader.Fields())
+	if err := reader.Pipe(writer); err != nil {
+		t.Fatalf("Pipe() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	out, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer out.Close()
+	if out.RecordsCount() != 4 {
+		t.Errorf("Expected 4 records, got %d", out.RecordsCount())
+	}
+}
+
+func TestPipeMemoRoundTrip(t *testing.T) {
+	notes := []string{"Первая заметка", "", strings.Repeat("long text ", 100)}
+
+	copies := map[string]func(*Reader, *Writer) error{
+		"Pipe": func(r *Reader, w *Writer) error { return r.Pipe(w) },
+		"Transform": func(r *Reader, w *Writer) error {
+			return Transform(r, w, func(rec *Record) (*Record, error) { return rec, nil })
+		},
+	}
+	for name, copyRecords := range copies {
+		t.Run(name, func(t *testing.T) {
+			reader, err := NewFromFile(createMemoTable(t, ".fpt", notes))
+			if err != nil {
+				t.Fatalf("NewFromFile() failed: %v", err)
+			}
+			defer reader.Close()
+
+			dir := t.TempDir()
+			file, err := os.Create(filepath.Join(dir, "out.dbf"))
+			if err != nil {
+				t.Fatalf("Create() failed: %v", err)
+			}
+			defer file.Close()
+
+			writer, err := NewWriter(file, reader.Fields(), WithWriterEncoding(charmap.CodePage866),
+				WithMemoOutput(filepath.Join(dir, "out.fpt")))
+			if err != nil {
+				t.Fatalf("NewWriter() failed: %v", err)
+			}
+			if err := copyRecords(reader, writer); err != nil {
+				t.Fatalf("%s() failed: %v", name, err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close() failed: %v", err)
+			}
+
+			out, err := NewFromFile(file.Name())
+			if err != nil {
+				t.Fatalf("NewFromFile() failed: %v", err)
+			}
+			defer out.Close()
+
+			records, err := out.ReadAllWithMemo()
+			if err != nil {
+				t.Fatalf("ReadAllWithMemo() failed: %v", err)
+			}
+			if len(records) != len(notes) {
+				t.Fatalf("Expected %d records, got %d", len(notes), len(records))
+			}
+			for i, want := range notes {
+				if got := records[i].Data["NOTES"]; got != want {
+					t.Errorf("Record %d: expected memo %q, got %q", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPipeSchemaMismatch(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, testWriterFields)
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+
+	if err := reader.Pipe(writer); !errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("Expected ErrSchemaMismatch, got %v", err)
+	}
+	if writer.RecordsCount() != 0 {
+		t.Errorf("Expected no records written, got %d", writer.RecordsCount())
+	}
+}
+
+func TestTransformSkipDeleted(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866(), WithSkipDeleted())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	writer, err := NewWriter(&bytes.Buffer{}, reader.Fields())
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+
+	calls := 0
+	err = Transform(reader, writer, func(rec *Record) (*Record, error) {
+		calls++
+		return rec, nil
+	})
+	if err != nil {
+		t.Fatalf("Transform() failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 callback, got %d", calls)
+	}
+	if writer.RecordsCount() != 1 {
+		t.Errorf("Expected 1 written record, got %d", writer.RecordsCount())
+	}
+}
+
+func TestTransformError(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	writer, err := NewWriter(&bytes.Buffer{}, reader.Fields())
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+
+	errBad := errors.New("bad record")
+	err = Transform(reader, writer, func(rec *Record) (*Record, error) {
+		if rec.Deleted {
+			return nil, errBad
+		}
+		return rec, nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("Expected errBad, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "record 1") {
+		t.Errorf("Expected error to mention record 1, got %v", err)
+	}
+}
+
+func TestWriterMemoOutputFPT(t *testing.T) {
+	memoPath := filepath.Join(t.TempDir(), "out.fpt")
+	writer, file := createTestWriter(t, []Field{
+		{Name: "NAME", Type: 'C', Length: 10},
+		{Name: "NOTES", Type: 'M', Length: 10},
+	}, WithMemoOutput(memoPath))
+
+	long := strings.Repeat("x", 100)
+	for _, notes := range []string{"short note", "", long} {
+		if err := writer.AppendRecord(&Record{Data: map[string]string{"NAME": "n", "NOTES": notes}}); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.FileType() != FoxPro2 {
+		t.Errorf("Expected FoxPro2 file type, got %s", reader.FileType())
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	// header occupies blocks 0-7; the first memo takes one block, the second two
+	blocks := []string{"8", "", "9"}
+	for i, want := range blocks {
+		if records[i].Data["NOTES"] != want {
+			t.Errorf("Record %d: expected block '%s', got '%s'", i, want, records[i].Data["NOTES"])
+		}
+	}
+
+	memo, err := os.ReadFile(memoPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if len(memo) != 512+3*64 {
+		t.Errorf("Expected memo file of %d bytes, got %d", 512+3*64, len(memo))
+	}
+	if next := binary.BigEndian.Uint32(memo[0:4]); next != 11 {
+		t.Errorf("Expected next free block 11, got %d", next)
+	}
+	if size := binary.BigEndian.Uint16(memo[6:8]); size != 64 {
+		t.Errorf("Expected block size 64, got %d", size)
+	}
+	if length := binary.BigEndian.Uint32(memo[8*64+4:]); length != 10 {
+		t.Errorf("Expected memo length 10, got %d", length)
+	}
+	if text := string(memo[8*64+8 : 8*64+18]); text != "short note" {
+		t.Errorf("Expected 'short note', got %q", text)
+	}
+}
+
+func TestWriterMemoOutputDBT(t *testing.T) {
+	memoPath := filepath.Join(t.TempDir(), "out.dbt")
+	writer, _ := createTestWriter(t, []Field{
+		{Name: "NOTES", Type: 'M', Length: 10},
+	}, WithMemoOutput(memoPath))
+
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"NOTES": "hello"}}); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	memo, err := os.ReadFile(memoPath)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if len(memo) != 1024 {
+		t.Errorf("Expected memo file of 1024 bytes, got %d", len(memo))
+	}
+	if next := binary.LittleEndian.Uint32(memo[0:4]); next != 2 {
+		t.Errorf("Expected next free block 2, got %d", next)
+	}
+	if text := string(memo[512:519]); text != "hello\x1a\x1a" {
+		t.Errorf("Expected 'hello' terminated by 0x1A 0x1A, got %q", text)
+	}
+}
+
+func TestTransformStopIteration(t *testing.T) {
+	reader, err := New(bytes.NewReader(createMinimalDBF()), WithCP866())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	writer, err := NewWriter(&bytes.Buffer{}, reader.Fields())
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+
+	err = Transform(reader, writer, func(rec *Record) (*Record, error) {
+		if writer.RecordsCount() == 1 {
+			return nil, fmt.Errorf("limit reached: %w", ErrStopIteration)
+		}
+		return rec, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected nil error for ErrStopIteration, got %v", err)
+	}
+	if writer.RecordsCount() != 1 {
+		t.Errorf("Expected 1 written record, got %d", writer.RecordsCount())
+	}
+}
+
+func TestFieldBuilders(t *testing.T) {
+	fields := []Field{
+		NewCharField("NAME", 10),
+		NewNumericField("PRICE", 8, 2),
+		NewDateField("SOLD"),
+		NewLogicalField("ACTIVE"),
+	}
+
+	expected := []Field{
+		{Name: "NAME", Type: 'C', Length: 10},
+		{Name: "PRICE", Type: 'N', Length: 8, DecimalCount: 2},
+		{Name: "SOLD", Type: 'D', Length: 8},
+		{Name: "ACTIVE", Type: 'L', Length: 1},
+	}
+	if !SchemaEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, fields); err != nil {
+		t.Errorf("NewWriter() failed: %v", err)
+	}
+
+	invalid := [][]Field{
+		{NewCharField("CUSTOMERNAME", 10)},
+		{NewCharField("NAME", 0)},
+		{NewNumericField("PRICE", 4, 3)},
+	}
+	for _, fields := range invalid {
+		if _, err := NewWriter(&bytes.Buffer{}, fields); err == nil {
+			t.Errorf("Expected NewWriter() error for %v", fields)
+		}
+	}
+}
+
+func TestWithStrictValidation(t *testing.T) {
+	writer, file := createTestWriter(t, testWriterFields, WithStrictValidation())
+
+	valid := &Record{Data: map[string]string{"NAME": "Alice", "AGE": "25", "BIRTHDATE": "19990115", "ACTIVE": "T"}}
+	if err := writer.AppendRecord(valid); err != nil {
+		t.Fatalf("AppendRecord() failed: %v", err)
+	}
+
+	invalid := []*Record{
+		{Data: map[string]string{"NAME": "Hello World!"}},
+		{Data: map[string]string{"NAME": "Bob", "AGE": "1000"}},
+		{Data: map[string]string{"NAME": "Bob", "BIRTHDATE": "19991301"}},
+	}
+	for _, rec := range invalid {
+		var validationErr *ValidationError
+		if err := writer.AppendRecord(rec); !errors.As(err, &validationErr) {
+			t.Errorf("Expected *ValidationError for %v, got %v", rec.Data, err)
+		}
+	}
+	if writer.RecordsCount() != 1 {
+		t.Errorf("Expected 1 record written, got %d", writer.RecordsCount())
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Data["NAME"] != "Alice" {
+		t.Errorf("Expected only the valid record, got %v", records)
+	}
+}
+
+func TestWithStrictValidationEncoded(t *testing.T) {
+	fields := []Field{NewCharField("NAME", 7)}
+
+	// Cyrillic takes one byte per character in CP866
+	writer, _ := createTestWriter(t, fields, WithWriterEncoding(charmap.CodePage866), WithStrictValidation())
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"NAME": "Привет!"}}); err != nil {
+		t.Errorf("AppendRecord() failed: %v", err)
+	}
+
+	// kanji take two bytes in Shift-JIS; the fourth one would be cut in half
+	writer, _ = createTestWriter(t, fields, WithWriterEncoding(japanese.ShiftJIS), WithStrictValidation())
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"NAME": "ｶﾅ日本"}}); err != nil {
+		t.Errorf("AppendRecord() failed: %v", err)
+	}
+	var validationErr *ValidationError
+	if err := writer.AppendRecord(&Record{Data: map[string]string{"NAME": "ｶﾅ日本語"}}); !errors.As(err, &validationErr) {
+		t.Errorf("Expected *ValidationError, got %v", err)
+	}
+	if writer.RecordsCount() != 1 {
+		t.Errorf("Expected 1 record written, got %d", writer.RecordsCount())
+	}
+}
+
+func TestWriterBinaryMemoBlock(t *testing.T) {
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "notes.dbf"))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	defer file.Close()
+
+	writer, err := NewWriter(file, []Field{
+		{Name: "ID", Type: 'N', Length: 3},
+		{Name: "NOTES", Type: 'M', Length: 4},
+	}, WithMemoOutput(filepath.Join(dir, "notes.fpt")))
+	if err != nil {
+		t.Fatalf("NewWriter() failed: %v", err)
+	}
+	notes := []string{"binary pointer", ""}
+	for i, text := range notes {
+		rec := &Record{Data: map[string]string{"ID": fmt.Sprint(i + 1), "NOTES": text}}
+		if err := writer.AppendRecord(rec); err != nil {
+			t.Fatalf("AppendRecord() failed: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	reader, err := NewFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("NewFromFile() failed: %v", err)
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAllWithMemo()
+	if err != nil {
+		t.Fatalf("ReadAllWithMemo() failed: %v", err)
+	}
+	for i, want := range notes {
+		if got := records[i].Data["NOTES"]; got != want {
+			t.Errorf("Record %d: expected %q, got %q", i, want, got)
+		}
+	}
+}